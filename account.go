@@ -0,0 +1,171 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const accountPrefix = "acc_"
+
+type Account struct {
+	ID                string    `json:"id"`
+	Version           int       `json:"version"`
+	CacheVersn        int       `json:"cacheVersn"`
+	Modified          time.Time `json:"modified"`
+	Created           time.Time `json:"created"`
+	SizeInBytes       int       `json:"sizeInBytes"`
+	Name              string    `json:"name"`
+	DisplayName       string    `json:"displayName"`
+	HostGroupID       string    `json:"hostGroupId"`
+	HostGroupName     string    `json:"hostGroupName"`
+	Host              string    `json:"host"`
+	Status            string    `json:"status"`
+	Roles             []string  `json:"roles"`
+	PropagationLabels []string  `json:"propagationLabels"`
+	SyncOnCreation    bool      `json:"syncOnCreation"`
+}
+
+type AccountCreate struct {
+	Name              string   `json:"name"`
+	HostGroupID       string   `json:"hostGroupId,omitempty"`
+	Roles             []string `json:"accountRoles,omitempty"`
+	PropagationLabels []string `json:"propagationLabels,omitempty"`
+	SyncOnCreation    bool     `json:"syncOnCreation"`
+}
+
+// accountName removes the acc_ prefix so that both forms of the account name
+// are accepted.
+func accountName(name string) string {
+	return strings.TrimPrefix(name, accountPrefix)
+}
+
+// ForAccount returns a client scoped to a tenant account of the main host,
+// which sends the requests to the acc_<name>/ endpoints. The returned client
+// shares the HTTP client and credentials of c.
+func (c *Client) ForAccount(name string) *Client {
+	scoped := *c
+
+	if scoped.mainURL == nil {
+		scoped.mainURL = c.baseURL
+	}
+	scoped.account = accountName(name)
+	scoped.baseURL = scoped.mainURL.JoinPath(accountPrefix + scoped.account)
+	scoped.initModules()
+
+	return &scoped
+}
+
+// AccountName returns the tenant account the client is scoped to, empty for the
+// main account.
+func (c *Client) AccountName() string {
+	return c.account
+}
+
+type AccountModule struct {
+	client *Client
+}
+
+// main returns the client of the main account, accounts are only managed from
+// the main host.
+func (m *AccountModule) main() *Client {
+	if m.client.mainURL == nil {
+		return m.client
+	}
+
+	c := *m.client
+	c.baseURL, c.mainURL, c.account = m.client.mainURL, nil, ""
+	c.initModules()
+	return &c
+}
+
+func (m *AccountModule) ListAccounts() ([]Account, error) {
+	c := m.main()
+
+	req, err := c.NewRequest(
+		http.MethodGet, "accounts",
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode[[]Account](resp)
+}
+
+func (m *AccountModule) CreateAccount(a AccountCreate) ([]Account, error) {
+	c := m.main()
+	a.Name = accountName(a.Name)
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(a); err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest(
+		http.MethodPost, "account",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode[[]Account](resp)
+}
+
+func (m *AccountModule) DeleteAccount(name string) ([]Account, error) {
+	c := m.main()
+
+	req, err := c.NewRequest(
+		http.MethodDelete, "account/"+accountPrefix+accountName(name),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode[[]Account](resp)
+}
+
+// PropagateContent syncs the content of the main account to the tenants
+// matching the propagation labels, every tenant when no label is given.
+func (m *AccountModule) PropagateContent(labels ...string) error {
+	c := m.main()
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string][]string{"propagationLabels": labels}); err != nil {
+		return err
+	}
+
+	req, err := c.NewRequest(
+		http.MethodPost, "accounts/content/sync",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req)
+	return err
+}
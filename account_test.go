@@ -0,0 +1,77 @@
+package xsoar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// recordPaths starts a server answering the requests with the body registered
+// for their method and path, or 404, and records the requested paths.
+func recordPaths(t *testing.T, bodies map[string]string) (*Client, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		mu.Lock()
+		paths = append(paths, key)
+		mu.Unlock()
+
+		body, ok := bodies[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(WithBaseURL(server.URL), WithAPIKey("key"), WithLogger(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), paths...)
+	}
+}
+
+func TestForAccount(t *testing.T) {
+	c, paths := recordPaths(t, map[string]string{
+		"GET /acc_tenant/lists": `[]`,
+		"GET /accounts":         `[]`,
+		"GET /lists":            `[]`,
+	})
+	scoped := c.ForAccount("acc_tenant")
+
+	if got := scoped.AccountName(); got != "tenant" {
+		t.Errorf("AccountName() = %q, want %q", got, "tenant")
+	}
+
+	if _, err := scoped.List.GetLists(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scoped.Account.ListAccounts(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scoped.Account.main().List.GetLists(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"GET /acc_tenant/lists", "GET /accounts", "GET /lists"}
+	got := paths()
+	if len(got) != len(want) {
+		t.Fatalf("paths = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("paths = %v, want %v", got, want)
+			break
+		}
+	}
+}
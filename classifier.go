@@ -0,0 +1,194 @@
+package xsoar
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type ClassifierType string
+
+const (
+	ClassificationClassifierType ClassifierType = "classification"
+	IncomingMapperClassifierType ClassifierType = "mapping-incoming"
+	OutgoingMapperClassifierType ClassifierType = "mapping-outgoing"
+)
+
+type ClassifierFieldMapping struct {
+	SimpleValue string `json:"simple"`
+	Complex     any    `json:"complex"`
+}
+
+type ClassifierMapping struct {
+	DontMapEventToLabels bool                              `json:"dontMapEventToLabels"`
+	InternalMapping      map[string]ClassifierFieldMapping `json:"internalMapping"`
+}
+
+type Classifier struct {
+	ID                  string                       `json:"id"`
+	Version             int                          `json:"version"`
+	CacheVersn          int                          `json:"cacheVersn"`
+	Modified            time.Time                    `json:"modified"`
+	Created             time.Time                    `json:"created"`
+	SizeInBytes         int                          `json:"sizeInBytes"`
+	PackID              string                       `json:"packID"`
+	PackName            string                       `json:"packName"`
+	ItemVersion         string                       `json:"itemVersion"`
+	FromServerVersion   string                       `json:"fromServerVersion"`
+	ToServerVersion     string                       `json:"toServerVersion"`
+	PropagationLabels   []string                     `json:"propagationLabels"`
+	DefinitionId        string                       `json:"definitionId"`
+	Name                string                       `json:"name"`
+	Type                ClassifierType               `json:"type"`
+	Description         string                       `json:"description"`
+	DefaultIncidentType string                       `json:"defaultIncidentType"`
+	KeyTypeMap          map[string]string            `json:"keyTypeMap"`
+	Transformer         any                          `json:"transformer"`
+	Mapping             map[string]ClassifierMapping `json:"mapping"`
+	Brands              []string                     `json:"brands"`
+	Instances           []string                     `json:"instances"`
+	Locked              bool                         `json:"locked"`
+	System              bool                         `json:"system"`
+	SortValues          []string                     `json:"sortValues"`
+	Unclassified        map[string]any               `json:"unclassifiedCases"`
+}
+
+type ClassifierSearch struct {
+	Classifiers []Classifier `json:"classifiers"`
+	Total       int          `json:"total"`
+}
+
+type ClassifierModule struct {
+	client *Client
+}
+
+func (m *ClassifierModule) ListClassifiers() ([]Classifier, error) {
+	req, err := m.client.NewRequest(
+		http.MethodPost, "classifier/search",
+		WithBody(strings.NewReader(`{}`)),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := Decode[ClassifierSearch](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return search.Classifiers, nil
+}
+
+func (m *ClassifierModule) GetClassifier(id string) (Classifier, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "classifier/"+id,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Classifier{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Classifier{}, err
+	}
+
+	return Decode[Classifier](resp)
+}
+
+// ExportClassifier returns the classifier or mapper in the content JSON
+// format, the caller is responsible for closing it.
+func (m *ClassifierModule) ExportClassifier(id string) (io.ReadCloser, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "classifier/download/"+id,
+		WithHeader("Accept", "application/octet-stream"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (m *ClassifierModule) ImportClassifier(filename string, content io.Reader) (Classifier, error) {
+	req, err := m.client.NewRequest(
+		http.MethodPost, "classifier/import",
+		WithFile("file", filename, content),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Classifier{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Classifier{}, err
+	}
+
+	return Decode[Classifier](resp)
+}
+
+func (m *ClassifierModule) DeleteClassifier(id string) error {
+	req, err := m.client.NewRequest(
+		http.MethodDelete, "classifier/"+id,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+// ValidateInstanceMappers checks that the classifier and mappers referenced by
+// the instance exist with the expected type.
+func (m *ClassifierModule) ValidateInstanceMappers(instance IntegrationInstanceUpsert) error {
+	classifiers, err := m.ListClassifiers()
+	if err != nil {
+		return err
+	}
+
+	types := make(map[string]ClassifierType, len(classifiers))
+	for _, c := range classifiers {
+		types[c.ID] = c.Type
+	}
+
+	for _, ref := range []struct {
+		id  string
+		typ ClassifierType
+	}{
+		{instance.MappingId, ClassificationClassifierType},
+		{instance.IncomingMapperId, IncomingMapperClassifierType},
+		{instance.OutgoingMapperId, OutgoingMapperClassifierType},
+	} {
+		if ref.id == "" {
+			continue
+		}
+
+		typ, ok := types[ref.id]
+		if !ok {
+			return errors.Errorf("%s %q does not exist", ref.typ, ref.id)
+		}
+		if typ != ref.typ {
+			return errors.Errorf("%q is a %s, expected %s", ref.id, typ, ref.typ)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+func printAPIKeys(out *printer, v any, keys []xsoar.APIKey) error {
+	var rows [][]string
+	for _, k := range keys {
+		rows = append(rows, []string{k.ID, k.Name, k.Username, k.Created.Format("2006-01-02")})
+	}
+	return out.print(v, []string{"ID", "NAME", "USER", "CREATED"}, rows)
+}
+
+var apiKeysCommands = map[string]command{
+	"list": {
+		usage: "",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			keys, err := c.Integration.ListAPIKeys()
+			if err != nil {
+				return err
+			}
+			return printAPIKeys(out, keys, keys)
+		},
+	},
+	"create": {
+		usage: "<name>",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "name"); err != nil {
+				return err
+			}
+			key, err := c.Integration.CreateAPIKey(args[0])
+			if err != nil {
+				return err
+			}
+
+			// the key is only known at creation, print it whatever the format
+			result := struct {
+				xsoar.APIKey
+				Key string `json:"apikey"`
+			}{key, key.APIKey}
+			return out.print(result, []string{"ID", "NAME", "KEY"}, [][]string{{key.ID, key.Name, key.APIKey}})
+		},
+	},
+	"delete": {
+		usage: "<id>",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "id"); err != nil {
+				return err
+			}
+			keys, err := c.Integration.DeleteAPIKey(args[0])
+			if err != nil {
+				return err
+			}
+			return printAPIKeys(out, keys, keys)
+		},
+	},
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+func printConfig(out *printer, config map[string]string) error {
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var rows [][]string
+	for _, k := range keys {
+		rows = append(rows, []string{k, config[k]})
+	}
+	return out.print(config, []string{"KEY", "VALUE"}, rows)
+}
+
+var configCommands = map[string]command{
+	"get": {
+		usage: "[key]...",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			config, err := c.Server.GetConfig()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				return printConfig(out, config.SysConfig)
+			}
+
+			selected := make(map[string]string, len(args))
+			for _, key := range args {
+				value, ok := config.SysConfig[key]
+				if !ok {
+					return fmt.Errorf("sysconfig key %q is not set", key)
+				}
+				selected[key] = value
+			}
+			return printConfig(out, selected)
+		},
+	},
+	"set": {
+		usage: "<key=value>...",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "key=value"); err != nil {
+				return err
+			}
+
+			data := make(map[string]string, len(args))
+			for _, arg := range args {
+				key, value, ok := strings.Cut(arg, "=")
+				if !ok {
+					return fmt.Errorf("invalid argument %q, expected key=value", arg)
+				}
+				data[key] = value
+			}
+
+			current, err := c.Server.GetConfig()
+			if err != nil {
+				return err
+			}
+
+			config, err := c.Server.UpdateConfig(xsoar.SystemConfigUpdate{Data: data, Version: current.Version})
+			if err != nil {
+				return err
+			}
+
+			updated := make(map[string]string, len(data))
+			for key := range data {
+				updated[key] = config.SysConfig[key]
+			}
+			return printConfig(out, updated)
+		},
+	},
+}
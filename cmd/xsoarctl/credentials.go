@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strconv"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+func printCredentials(out *printer, v any, credentials []xsoar.Credential) error {
+	var rows [][]string
+	for _, c := range credentials {
+		rows = append(rows, []string{c.ID, c.Name, c.User, strconv.FormatBool(c.HasPassword), strconv.FormatBool(c.HasCertificate)})
+	}
+	return out.print(v, []string{"ID", "NAME", "USER", "PASSWORD", "CERTIFICATE"}, rows)
+}
+
+var credentialsCommands = map[string]command{
+	"list": {
+		usage: "",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			search, err := c.Integration.ListCredentials()
+			if err != nil {
+				return err
+			}
+			return printCredentials(out, search.Credentials, search.Credentials)
+		},
+	},
+	"upsert": {
+		usage: "<file|->",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "file"); err != nil {
+				return err
+			}
+
+			var credential xsoar.CredentialUpsert
+			if err := readInput(args[0], &credential); err != nil {
+				return err
+			}
+
+			result, err := c.Integration.UpsertCredential(credential)
+			if err != nil {
+				return err
+			}
+			return printCredentials(out, result, []xsoar.Credential{result})
+		},
+	},
+	"delete": {
+		usage: "<id>",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "id"); err != nil {
+				return err
+			}
+			if err := c.Integration.DeleteCredential(args[0]); err != nil {
+				return err
+			}
+			return out.message("credential %s deleted", args[0])
+		},
+	},
+}
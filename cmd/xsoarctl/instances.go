@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+func printInstances(out *printer, v any, instances []xsoar.IntegrationInstance) error {
+	var rows [][]string
+	for _, i := range instances {
+		engine := i.Engine
+		if engine == "" {
+			engine = i.EngineGroup
+		}
+		rows = append(rows, []string{i.ID, i.Name, i.Brand, strconv.FormatBool(i.Enabled), engine})
+	}
+	return out.print(v, []string{"ID", "NAME", "BRAND", "ENABLED", "ENGINE"}, rows)
+}
+
+func findInstance(c *xsoar.Client, ref string) (xsoar.IntegrationInstance, error) {
+	instances, err := c.Integration.GetInstances()
+	if err != nil {
+		return xsoar.IntegrationInstance{}, err
+	}
+
+	for _, i := range instances {
+		if i.ID == ref || i.Name == ref {
+			return i, nil
+		}
+	}
+
+	return xsoar.IntegrationInstance{}, fmt.Errorf("instance %q not found", ref)
+}
+
+var instancesCommands = map[string]command{
+	"list": {
+		usage: "",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			instances, err := c.Integration.GetInstances()
+			if err != nil {
+				return err
+			}
+			return printInstances(out, instances, instances)
+		},
+	},
+	"get": {
+		usage: "<id|name>",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "id"); err != nil {
+				return err
+			}
+			instance, err := findInstance(c, args[0])
+			if err != nil {
+				return err
+			}
+			return printInstances(out, instance, []xsoar.IntegrationInstance{instance})
+		},
+	},
+	"upsert": {
+		usage: "<file|->  (instance with the API field names, enabled: true|false)",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "file"); err != nil {
+				return err
+			}
+
+			var instance xsoar.IntegrationInstanceUpsert
+			if err := readInput(args[0], &instance); err != nil {
+				return err
+			}
+
+			result, err := c.Integration.UpsertInstance(instance)
+			if err != nil {
+				return err
+			}
+			return printInstances(out, result, []xsoar.IntegrationInstance{result})
+		},
+	},
+	"delete": {
+		usage: "<id|name>",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "id"); err != nil {
+				return err
+			}
+			instance, err := findInstance(c, args[0])
+			if err != nil {
+				return err
+			}
+			if err := c.Integration.DeleteInstance(instance.ID); err != nil {
+				return err
+			}
+			return out.message("instance %s deleted", instance.Name)
+		},
+	},
+	"test": {
+		usage: "<id|name>",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "id"); err != nil {
+				return err
+			}
+			instance, err := findInstance(c, args[0])
+			if err != nil {
+				return err
+			}
+			upsert, err := instance.ToUpsert()
+			if err != nil {
+				return err
+			}
+			result, err := c.Integration.TestInstance(upsert)
+			if err != nil {
+				return err
+			}
+			if err := out.print(result, []string{"SUCCESS", "MESSAGE"}, [][]string{{strconv.FormatBool(result.Success), result.Message}}); err != nil {
+				return err
+			}
+			if !result.Success {
+				return fmt.Errorf("test of instance %q failed", instance.Name)
+			}
+			return nil
+		},
+	},
+}
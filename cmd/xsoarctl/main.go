@@ -0,0 +1,136 @@
+// Command xsoarctl manages a Cortex XSOAR server from the command line.
+//
+// The server and credentials are read from the DEMISTO_BASE_URL,
+// DEMISTO_API_KEY, DEMISTO_USERNAME, DEMISTO_PASSWORD and DEMISTO_VERIFY_SSL
+// environment variables, from a profile of the YAML file ~/.xsoar/config, or
+// from the global flags.
+//
+// Commands reading a file take YAML or JSON using the field names of the API,
+// or stdin when the file is "-".
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+type command struct {
+	usage string
+	run   func(c *xsoar.Client, out *printer, args []string) error
+}
+
+var commands = map[string]map[string]command{
+	"users":       usersCommands,
+	"roles":       rolesCommands,
+	"apikeys":     apiKeysCommands,
+	"credentials": credentialsCommands,
+	"instances":   instancesCommands,
+	"config":      configCommands,
+}
+
+func usage(fs *flag.FlagSet) {
+	fmt.Fprintf(fs.Output(), "Usage: xsoarctl [flags] <resource> <action> [args]\n\nResources:\n")
+
+	resources := make([]string, 0, len(commands))
+	for r := range commands {
+		resources = append(resources, r)
+	}
+	sort.Strings(resources)
+
+	for _, r := range resources {
+		actions := make([]string, 0, len(commands[r]))
+		for a, cmd := range commands[r] {
+			actions = append(actions, fmt.Sprintf("    %s %s %s", r, a, cmd.usage))
+		}
+		sort.Strings(actions)
+		fmt.Fprintln(fs.Output(), strings.Join(actions, "\n"))
+	}
+
+	fmt.Fprintf(fs.Output(), "\nFlags:\n")
+	fs.PrintDefaults()
+}
+
+// hasDefaultProfile reports whether the config file sets a default profile,
+// an unreadable config file is reported by WithProfile.
+func hasDefaultProfile() bool {
+	path, err := xsoar.ConfigPath()
+	if err != nil {
+		return false
+	}
+
+	config, err := xsoar.LoadConfig(path)
+	if err != nil {
+		return !os.IsNotExist(err)
+	}
+	return config.Default != ""
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("xsoarctl", flag.ContinueOnError)
+	output := fs.String("o", "table", "output format: table, json or yaml")
+	profile := fs.String("profile", "", "profile of ~/.xsoar/config, defaults to XSOAR_PROFILE or the default profile")
+	baseURL := fs.String("url", "", "server URL, overrides DEMISTO_BASE_URL")
+	apiKey := fs.String("api-key", "", "API key, overrides DEMISTO_API_KEY")
+	insecure := fs.Bool("insecure", false, "skip TLS certificate verification")
+	debug := fs.Bool("debug", false, "log HTTP requests")
+	fs.Usage = func() { usage(fs) }
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return fmt.Errorf("missing resource or action")
+	}
+
+	resource, action := fs.Arg(0), fs.Arg(1)
+	cmd, ok := commands[resource][action]
+	if !ok {
+		fs.Usage()
+		return fmt.Errorf("unknown command %q %q", resource, action)
+	}
+
+	out, err := newPrinter(os.Stdout, *output)
+	if err != nil {
+		return err
+	}
+
+	var options []xsoar.ClientOption
+	if *profile != "" || os.Getenv("XSOAR_PROFILE") != "" || hasDefaultProfile() {
+		options = append(options, xsoar.WithProfile(*profile))
+	}
+	if *baseURL != "" {
+		options = append(options, xsoar.WithBaseURL(*baseURL))
+	}
+	if *apiKey != "" {
+		options = append(options, xsoar.WithAPIKey(*apiKey))
+	}
+	if *insecure {
+		options = append(options, xsoar.WithoutSSLVerify())
+	}
+	if !*debug {
+		options = append(options, xsoar.WithLogger(nil))
+	}
+
+	client, err := xsoar.NewClient(options...)
+	if err != nil {
+		return err
+	}
+
+	return cmd.run(client, out, fs.Args()[2:])
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+	"gopkg.in/yaml.v3"
+)
+
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case "table", "json", "yaml":
+		return &printer{w: w, format: format}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// toGeneric converts v to maps and slices following its json tags, so that
+// the YAML output uses the same keys as the JSON one.
+func toGeneric(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+	return generic, json.Unmarshal(data, &generic)
+}
+
+// print writes v in the selected format, tables only show the given columns
+// with one row per item.
+func (p *printer) print(v any, headers []string, rows [][]string) error {
+	switch p.format {
+	case "json":
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case "yaml":
+		generic, err := toGeneric(v)
+		if err != nil {
+			return err
+		}
+		encoder := yaml.NewEncoder(p.w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(generic); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (p *printer) message(format string, args ...any) error {
+	if p.format != "table" {
+		return nil
+	}
+	_, err := fmt.Fprintf(p.w, format+"\n", args...)
+	return err
+}
+
+// readInput decodes a YAML or JSON file, or stdin when path is "-", into v
+// following its json tags.
+func readInput(path string, v any) error {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	return xsoar.DecodeDocument(r, v)
+}
+
+func requireArgs(args []string, names ...string) error {
+	if len(args) < len(names) {
+		return fmt.Errorf("missing argument(s): %s", strings.Join(names[len(args):], ", "))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+func printRoles(out *printer, v any, roles []xsoar.Role) error {
+	var rows [][]string
+	for _, r := range roles {
+		rows = append(rows, []string{r.ID, r.Name, strings.Join(r.NestedRoles, ","), fmt.Sprint(len(r.Permissions))})
+	}
+	return out.print(v, []string{"ID", "NAME", "NESTED ROLES", "PERMISSIONS"}, rows)
+}
+
+var rolesCommands = map[string]command{
+	"get": {
+		usage: "[name]",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			roles, err := c.Role.GetRoles()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				return printRoles(out, roles, roles)
+			}
+
+			for _, r := range roles {
+				if r.Name == args[0] || r.ID == args[0] {
+					return printRoles(out, r, []xsoar.Role{r})
+				}
+			}
+			return fmt.Errorf("role %q not found", args[0])
+		},
+	},
+	"apply": {
+		usage: "<file|->",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "file"); err != nil {
+				return err
+			}
+
+			var role xsoar.Role
+			if err := readInput(args[0], &role); err != nil {
+				return err
+			}
+
+			existing, err := c.Role.GetRoles()
+			if err != nil {
+				return err
+			}
+			for _, r := range existing {
+				if r.Name == role.Name && role.ID == "" {
+					role.ID, role.Version = r.ID, r.Version
+				}
+			}
+
+			roles, err := c.Role.UpsertRole(role)
+			if err != nil {
+				return err
+			}
+			return printRoles(out, roles, roles)
+		},
+	},
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+func printUsers(out *printer, users []xsoar.User) error {
+	var rows [][]string
+	for _, u := range users {
+		rows = append(rows, []string{u.Username, u.Name, u.Email, strings.Join(u.AllRoles, ","), strconv.FormatBool(u.Disabled)})
+	}
+	return out.print(users, []string{"USERNAME", "NAME", "EMAIL", "ROLES", "DISABLED"}, rows)
+}
+
+var usersCommands = map[string]command{
+	"list": {
+		usage: "",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			users, err := c.User.GetUsers()
+			if err != nil {
+				return err
+			}
+			return printUsers(out, users)
+		},
+	},
+	"disable": {
+		usage: "<id>",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "id"); err != nil {
+				return err
+			}
+			users, err := c.User.Disable(args[0])
+			if err != nil {
+				return err
+			}
+			return printUsers(out, users)
+		},
+	},
+	"enable": {
+		usage: "<id>",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "id"); err != nil {
+				return err
+			}
+			users, err := c.User.Enable(args[0])
+			if err != nil {
+				return err
+			}
+			return printUsers(out, users)
+		},
+	},
+	"delete": {
+		usage: "<id>...",
+		run: func(c *xsoar.Client, out *printer, args []string) error {
+			if err := requireArgs(args, "id"); err != nil {
+				return err
+			}
+			users, err := c.User.Delete(args...)
+			if err != nil {
+				return err
+			}
+			return printUsers(out, users)
+		},
+	},
+}
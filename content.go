@@ -0,0 +1,164 @@
+package xsoar
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ContentItemType string
+
+const (
+	ScriptContentItem         ContentItemType = "automation"
+	IntegrationContentItem    ContentItemType = "integration"
+	PlaybookContentItem       ContentItemType = "playbook"
+	LayoutContentItem         ContentItemType = "layoutscontainer"
+	IncidentFieldContentItem  ContentItemType = "incidentfield"
+	IncidentTypeContentItem   ContentItemType = "incidenttype"
+	IndicatorFieldContentItem ContentItemType = "indicatorfield"
+	IndicatorTypeContentItem  ContentItemType = "reputation"
+	ClassifierContentItem     ContentItemType = "classifier"
+	DashboardContentItem      ContentItemType = "dashboard"
+	WidgetContentItem         ContentItemType = "widget"
+	ReportContentItem         ContentItemType = "report"
+	ListContentItem           ContentItemType = "list"
+	JobContentItem            ContentItemType = "job"
+	UnknownContentItem        ContentItemType = "unknown"
+)
+
+var contentItemTypes = []ContentItemType{
+	ScriptContentItem, IntegrationContentItem, PlaybookContentItem, LayoutContentItem,
+	IncidentFieldContentItem, IncidentTypeContentItem, IndicatorFieldContentItem, IndicatorTypeContentItem,
+	ClassifierContentItem, DashboardContentItem, WidgetContentItem, ReportContentItem, ListContentItem, JobContentItem,
+}
+
+type ContentItem struct {
+	Type ContentItemType `json:"type"`
+	ID   string          `json:"id"`
+	Name string          `json:"name"`
+	Path string          `json:"path"`
+}
+
+type ContentImportOptions struct {
+	// Overwrite items already existing on the server
+	Overwrite bool
+
+	// Skip items already existing on the server instead of failing
+	SkipExisting bool
+}
+
+type ContentModule struct {
+	client *Client
+}
+
+// ExportCustomContent returns the custom content bundle of the server as a
+// tar.gz stream, the caller is responsible for closing it.
+func (m *ContentModule) ExportCustomContent() (io.ReadCloser, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "content/bundle",
+		WithHeader("Accept", "application/octet-stream"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (m *ContentModule) ImportCustomContent(bundle io.Reader, opt ContentImportOptions) error {
+	req, err := m.client.NewRequest(
+		http.MethodPost, "content/bundle",
+		WithFile("file", "content.tar.gz", bundle),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	q := req.URL.Query()
+	q.Set("overwrite", strconv.FormatBool(opt.Overwrite))
+	q.Set("skipExisting", strconv.FormatBool(opt.SkipExisting))
+	req.URL.RawQuery = q.Encode()
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func contentItemType(filename string) ContentItemType {
+	base := strings.ToLower(path.Base(filename))
+	for _, t := range contentItemTypes {
+		if strings.HasPrefix(base, string(t)+"-") {
+			return t
+		}
+	}
+	return UnknownContentItem
+}
+
+// ListBundleItems reads a custom content bundle offline and returns the
+// items it contains sorted by type then name.
+func ListBundleItems(bundle io.Reader) ([]ContentItem, error) {
+	gz, err := gzip.NewReader(bundle)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var items []ContentItem
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		item := ContentItem{Type: contentItemType(header.Name), Path: header.Name}
+
+		var meta struct {
+			ID   string `json:"id" yaml:"id"`
+			Name string `json:"name" yaml:"name"`
+		}
+
+		switch path.Ext(header.Name) {
+		case ".yml", ".yaml":
+			_ = yaml.NewDecoder(tr).Decode(&meta)
+		case ".json":
+			_ = json.NewDecoder(tr).Decode(&meta)
+		}
+
+		item.ID, item.Name = meta.ID, meta.Name
+		if item.Name == "" {
+			item.Name = strings.TrimSuffix(path.Base(header.Name), path.Ext(header.Name))
+		}
+
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	return items, nil
+}
@@ -0,0 +1,127 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type contextQuery struct {
+	Query string `json:"query"`
+}
+
+type entryExecution struct {
+	InvestigationID string `json:"investigationId"`
+	Data            string `json:"data"`
+}
+
+// errorEntryType is the type of the war room entries reporting a failure.
+const errorEntryType = 4
+
+// executionEntry holds the fields of the returned war room entries needed to
+// detect failures, the entries are decoded leniently as they carry many more.
+type executionEntry struct {
+	Type     int `json:"type"`
+	Contents any `json:"contents"`
+}
+
+// contextSelector wraps a dot path or DT selector such as
+// `Account(val.ID == 1).Username` in the ${} syntax expected by the server.
+func contextSelector(path string) string {
+	if strings.HasPrefix(path, "${") {
+		return path
+	}
+	return "${" + path + "}"
+}
+
+// GetContext evaluates the path against the investigation context and decodes
+// the result into T. An empty path returns the whole context.
+func GetContext[T any](m *InvestigationModule, investigationID, path string) (T, error) {
+	var zero T
+
+	query := contextQuery{}
+	if path != "" {
+		query.Query = contextSelector(path)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(query); err != nil {
+		return zero, err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, fmt.Sprintf("investigation/%s/context", investigationID),
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return zero, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return zero, err
+	}
+
+	return Decode[T](resp)
+}
+
+func (m *InvestigationModule) execute(investigationID, command string) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(entryExecution{investigationID, command}); err != nil {
+		return err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "entry/execute/sync",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	raw, err := Decode[[]json.RawMessage](resp)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range raw {
+		var entry executionEntry
+		if err := json.Unmarshal(r, &entry); err != nil {
+			return errors.Wrap(err, "invalid entry")
+		}
+
+		if entry.Type == errorEntryType {
+			return errors.Errorf("command %s failed: %v", strings.SplitN(command, " ", 2)[0], entry.Contents)
+		}
+	}
+
+	return nil
+}
+
+// SetContext stores the JSON encoding of value under key in the
+// investigation context, replacing any existing value.
+func (m *InvestigationModule) SetContext(investigationID, key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return m.execute(investigationID, fmt.Sprintf("!Set key=%q value=%q stringify=false", key, data))
+}
+
+func (m *InvestigationModule) DeleteContext(investigationID, key string) error {
+	return m.execute(investigationID, fmt.Sprintf("!DeleteContext key=%q", key))
+}
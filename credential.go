@@ -18,6 +18,7 @@ type Credential struct {
 	Locked             bool      `json:"locked"`
 	Modified           time.Time `json:"modified"`
 	Name               string    `json:"name"`
+	PropagationLabels  []string  `json:"propagationLabels"`
 	SizeInBytes        int       `json:"sizeInBytes"`
 	User               string    `json:"user"`
 	VaultInstanceId    string    `json:"vaultInstanceId"`
@@ -26,15 +27,33 @@ type Credential struct {
 }
 
 type CredentialUpsert struct {
-	ID             string `json:"id,omitempty"`
-	HasCertificate bool   `json:"hasCertificate,omitempty"`
-	HasPassword    bool   `json:"hasCertificatePass,omitempty"`
-	Name           string `json:"name,omitempty"`
-	Password       string `json:"password,omitempty"`
-	SSHKey         string `json:"sshkey,omitempty"`
-	User           string `json:"user,omitempty"`
-	Version        int    `json:"version,omitempty"`
-	Workgroup      string `json:"workgroup,omitempty"`
+	ID                 string   `json:"id,omitempty"`
+	HasCertificate     bool     `json:"hasCertificate,omitempty"`
+	HasCertificatePass bool     `json:"hasCertificatePass,omitempty"`
+	HasPassword        bool     `json:"hasPassword,omitempty"`
+	Name               string   `json:"name,omitempty"`
+	Password           string   `json:"password,omitempty"`
+	PropagationLabels  []string `json:"propagationLabels,omitempty"`
+	SSHKey             string   `json:"sshkey,omitempty"`
+	User               string   `json:"user,omitempty"`
+	Version            int      `json:"version,omitempty"`
+	Workgroup          string   `json:"workgroup,omitempty"`
+}
+
+// ToUpsert returns the credential as an update without secrets, the flags of
+// the stored secrets are sent back as returned by the server.
+func (c Credential) ToUpsert() CredentialUpsert {
+	return CredentialUpsert{
+		ID:                 c.ID,
+		HasCertificate:     c.HasCertificate,
+		HasCertificatePass: c.HasCertificatePass,
+		HasPassword:        c.HasPassword,
+		Name:               c.Name,
+		PropagationLabels:  c.PropagationLabels,
+		User:               c.User,
+		Version:            c.Version,
+		Workgroup:          c.Workgroup,
+	}
 }
 
 type CredentialSearch struct {
@@ -0,0 +1,93 @@
+package xsoar
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.Errorf("invalid %s value %q", f.name, s)
+	}
+
+	if v < f.min || v > f.max {
+		return 0, errors.Errorf("%s value %d out of range [%d-%d]", f.name, v, f.min, f.max)
+	}
+
+	return v, nil
+}
+
+func (f cronField) validate(expr string) error {
+	for _, part := range strings.Split(expr, ",") {
+		rng, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n <= 0 {
+				return errors.Errorf("invalid %s step %q", f.name, step)
+			}
+		}
+
+		if rng == "*" {
+			continue
+		}
+
+		from, to, isRange := strings.Cut(rng, "-")
+		start, err := f.value(from)
+		if err != nil {
+			return err
+		}
+
+		if !isRange {
+			continue
+		}
+
+		end, err := f.value(to)
+		if err != nil {
+			return err
+		}
+
+		if start > end {
+			return errors.Errorf("invalid %s range %q", f.name, rng)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCron checks a standard five fields cron expression.
+func ValidateCron(expr string) error {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return errors.Errorf("cron expression %q must have %d fields, got %d", expr, len(cronFields), len(fields))
+	}
+
+	for i, field := range fields {
+		if err := cronFields[i].validate(field); err != nil {
+			return errors.Wrapf(err, "cron expression %q", expr)
+		}
+	}
+
+	return nil
+}
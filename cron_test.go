@@ -0,0 +1,45 @@
+package xsoar
+
+import "testing"
+
+func TestValidateCron(t *testing.T) {
+	tests := []struct {
+		expr  string
+		valid bool
+	}{
+		{"* * * * *", true},
+		{"0 0 1 1 0", true},
+		{"59 23 31 12 7", true},
+		{"*/15 * * * *", true},
+		{"0 8-18/2 * * MON-FRI", true},
+		{"0 0 * jan,jul sun", true},
+		{"0,30 9,17 1-15 * *", true},
+		{"* * * *", false},
+		{"* * * * * *", false},
+		{"", false},
+		{"60 * * * *", false},
+		{"* 24 * * *", false},
+		{"* * 0 * *", false},
+		{"* * * 13 *", false},
+		{"* * * * 8", false},
+		{"* * * FOO *", false},
+		{"* * * * MON-", false},
+		{"30-10 * * * *", false},
+		{"*/0 * * * *", false},
+		{"*/-1 * * * *", false},
+		{"*/x * * * *", false},
+		{"a * * * *", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			err := ValidateCron(tt.expr)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateCron(%q) = %v, want nil", tt.expr, err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("ValidateCron(%q) = nil, want an error", tt.expr)
+			}
+		})
+	}
+}
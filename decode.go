@@ -2,7 +2,10 @@ package xsoar
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
+
+	"gopkg.in/yaml.v3"
 )
 
 func Decode[T any](resp *http.Response) (T, error) {
@@ -20,3 +23,19 @@ func GetMessage(resp *http.Response) string {
 	}
 	return string(message)
 }
+
+// DecodeDocument reads a YAML or JSON document into v. The document is
+// converted to JSON first so that the json tags of the client types apply.
+func DecodeDocument(r io.Reader, v any) error {
+	var doc any
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,230 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type EngineStatus string
+
+const (
+	EngineConnected    EngineStatus = "connected"
+	EngineDisconnected EngineStatus = "disconnected"
+)
+
+type Engine struct {
+	ID              string       `json:"id"`
+	Version         int          `json:"version"`
+	CacheVersn      int          `json:"cacheVersn"`
+	Modified        time.Time    `json:"modified"`
+	Created         time.Time    `json:"created"`
+	SizeInBytes     int          `json:"sizeInBytes"`
+	Name            string       `json:"name"`
+	Hostname        string       `json:"hostname"`
+	Status          EngineStatus `json:"status"`
+	ServerVersion   string       `json:"serverVersion"`
+	OS              string       `json:"os"`
+	Arch            string       `json:"arch"`
+	PkgType         string       `json:"pkgType"`
+	EngineGroupID   string       `json:"engineGroupId"`
+	LastHeartbeat   time.Time    `json:"lastHeartbeat"`
+	Connections     int          `json:"connections"`
+	IsUpgradeNeeded bool         `json:"isUpgradeNeeded"`
+}
+
+func (e Engine) Connected() bool {
+	return e.Status == EngineConnected
+}
+
+type EngineGroup struct {
+	ID          string    `json:"id"`
+	Version     int       `json:"version"`
+	CacheVersn  int       `json:"cacheVersn"`
+	Modified    time.Time `json:"modified"`
+	Created     time.Time `json:"created"`
+	SizeInBytes int       `json:"sizeInBytes"`
+	Name        string    `json:"name"`
+	EngineIDs   []string  `json:"engineIds"`
+}
+
+type EngineCreate struct {
+	Name    string `json:"name"`
+	PkgType string `json:"pkgType"`
+}
+
+type EngineGroupUpsert struct {
+	ID        string   `json:"id,omitempty"`
+	Name      string   `json:"name"`
+	EngineIDs []string `json:"engineIds"`
+	Version   int      `json:"version,omitempty"`
+}
+
+type EngineModule struct {
+	client *Client
+}
+
+func (m *EngineModule) ListEngines() (Engines, error) {
+	req, err := m.client.NewRequest(
+		http.MethodPost, "engines/search",
+		WithBody(strings.NewReader(`{}`)),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Engines{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Engines{}, err
+	}
+
+	return Decode[Engines](resp)
+}
+
+func (m *EngineModule) GetEngineByName(name string) (Engine, error) {
+	engines, err := m.ListEngines()
+	if err != nil {
+		return Engine{}, err
+	}
+
+	for _, engine := range engines.Engines {
+		if engine.Name == name {
+			return engine, nil
+		}
+	}
+
+	return Engine{}, errors.Errorf("engine %q not found", name)
+}
+
+func (m *EngineModule) GetEngineGroupByName(name string) (EngineGroup, error) {
+	engines, err := m.ListEngines()
+	if err != nil {
+		return EngineGroup{}, err
+	}
+
+	for _, group := range engines.EngineGroups {
+		if group.Name == name {
+			return group, nil
+		}
+	}
+
+	return EngineGroup{}, errors.Errorf("engine group %q not found", name)
+}
+
+func (m *EngineModule) CreateEngine(e EngineCreate) (Engine, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(e); err != nil {
+		return Engine{}, err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "engines",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Engine{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Engine{}, err
+	}
+
+	return Decode[Engine](resp)
+}
+
+// DownloadInstaller returns the installer of an engine for the given package
+// type (one of Engines.PkgTypes), the caller is responsible for closing it.
+func (m *EngineModule) DownloadInstaller(id, pkgType string) (io.ReadCloser, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, fmt.Sprintf("engines/download/%s/%s", id, pkgType),
+		WithHeader("Accept", "application/octet-stream"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (m *EngineModule) DeleteEngine(id string) error {
+	req, err := m.client.NewRequest(
+		http.MethodDelete, "engines/"+id,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func (m *EngineModule) UpsertEngineGroup(g EngineGroupUpsert) (EngineGroup, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(g); err != nil {
+		return EngineGroup{}, err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "engines/groups",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return EngineGroup{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return EngineGroup{}, err
+	}
+
+	return Decode[EngineGroup](resp)
+}
+
+func (m *EngineModule) DeleteEngineGroup(id string) error {
+	req, err := m.client.NewRequest(
+		http.MethodDelete, "engines/groups/"+id,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func (m *EngineModule) CheckConnectivity(id string) (Engine, error) {
+	req, err := m.client.NewRequest(
+		http.MethodPost, fmt.Sprintf("engines/%s/test", id),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Engine{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Engine{}, err
+	}
+
+	return Decode[Engine](resp)
+}
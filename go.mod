@@ -7,4 +7,5 @@ require github.com/hashicorp/go-retryablehttp v0.7.7
 require (
 	github.com/hashicorp/go-cleanhttp v0.5.2 // indirect
 	github.com/pkg/errors v0.9.1
+	gopkg.in/yaml.v3 v3.0.1
 )
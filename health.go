@@ -0,0 +1,259 @@
+package xsoar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type HealthEventType string
+
+const (
+	HealthEventErroring          HealthEventType = "erroring"
+	HealthEventRecovered         HealthEventType = "recovered"
+	HealthEventStale             HealthEventType = "stale"
+	HealthEventDroppedIncreasing HealthEventType = "dropped_increasing"
+)
+
+type HealthEvent struct {
+	Type     HealthEventType
+	Instance string
+	Brand    string
+	Health   InstanceHealth
+	Previous InstanceHealth
+	Time     time.Time
+}
+
+type HealthMonitorOption func(*HealthMonitor)
+
+type HealthMonitor struct {
+	module *IntegrationModule
+
+	// Polling interval of the integrations health
+	interval time.Duration
+
+	// Duration without fetch after which an instance is reported stale
+	staleAfter time.Duration
+
+	callbacks []func(HealthEvent)
+	events    chan HealthEvent
+	errors    chan error
+
+	mu       sync.RWMutex
+	snapshot map[string]InstanceHealth
+	stale    map[string]bool
+}
+
+func WithHealthInterval(interval time.Duration) HealthMonitorOption {
+	return func(h *HealthMonitor) {
+		h.interval = interval
+	}
+}
+
+func WithStaleAfter(d time.Duration) HealthMonitorOption {
+	return func(h *HealthMonitor) {
+		h.staleAfter = d
+	}
+}
+
+func WithHealthCallback(fn func(HealthEvent)) HealthMonitorOption {
+	return func(h *HealthMonitor) {
+		h.callbacks = append(h.callbacks, fn)
+	}
+}
+
+func (m *IntegrationModule) NewHealthMonitor(options ...HealthMonitorOption) (*HealthMonitor, error) {
+	h := &HealthMonitor{
+		module:     m,
+		interval:   time.Minute,
+		staleAfter: 30 * time.Minute,
+		events:     make(chan HealthEvent, 64),
+		errors:     make(chan error, 1),
+		stale:      make(map[string]bool),
+	}
+
+	for _, fn := range options {
+		fn(h)
+	}
+
+	if h.interval <= 0 {
+		return nil, errors.Errorf("invalid health interval %s", h.interval)
+	}
+
+	if h.staleAfter <= 0 {
+		return nil, errors.Errorf("invalid stale duration %s", h.staleAfter)
+	}
+
+	return h, nil
+}
+
+// Events returns the channel on which health events are published. Events are
+// dropped when the channel is full.
+func (h *HealthMonitor) Events() <-chan HealthEvent {
+	return h.events
+}
+
+// Errors returns the channel on which polling errors are published.
+func (h *HealthMonitor) Errors() <-chan error {
+	return h.errors
+}
+
+// Run polls the integrations health until ctx is cancelled.
+func (h *HealthMonitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := h.Poll(); err != nil {
+			select {
+			case h.errors <- err:
+			default:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *HealthMonitor) Poll() error {
+	search, err := h.module.SearchIntegrations(nil)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	current := make(map[string]InstanceHealth, len(search.Health))
+	for _, health := range search.Health {
+		current[health.Instance] = health
+	}
+
+	h.mu.Lock()
+	previous := h.snapshot
+	h.snapshot = current
+	var events []HealthEvent
+	for name, health := range current {
+		prev, known := previous[name]
+		event := HealthEvent{Instance: name, Brand: health.Brand, Health: health, Previous: prev, Time: now}
+
+		switch {
+		case health.LastError != "" && (!known || prev.LastError == ""):
+			event.Type = HealthEventErroring
+			events = append(events, event)
+		case health.LastError == "" && known && prev.LastError != "":
+			event.Type = HealthEventRecovered
+			events = append(events, event)
+		}
+
+		if known && health.IncidentsDropped > prev.IncidentsDropped {
+			event.Type = HealthEventDroppedIncreasing
+			events = append(events, event)
+		}
+
+		isStale := !health.LastPullTime.IsZero() && now.Sub(health.LastPullTime) > h.staleAfter
+		if isStale && !h.stale[name] {
+			event.Type = HealthEventStale
+			events = append(events, event)
+		}
+		h.stale[name] = isStale
+	}
+	h.mu.Unlock()
+
+	for _, event := range events {
+		h.emit(event)
+	}
+
+	return nil
+}
+
+func (h *HealthMonitor) emit(event HealthEvent) {
+	for _, fn := range h.callbacks {
+		fn(event)
+	}
+
+	select {
+	case h.events <- event:
+	default:
+	}
+}
+
+type HealthMetric struct {
+	Instance string
+	Brand    string
+	Name     string
+	Value    float64
+}
+
+// Snapshot returns the last polled health as gauges named after the
+// Prometheus exposition format, sorted by metric name then instance. Instances
+// that never pulled have no last pull timestamp.
+func (h *HealthMonitor) Snapshot() []HealthMetric {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var metrics []HealthMetric
+	for name, health := range h.snapshot {
+		errored := 0.0
+		if health.LastError != "" {
+			errored = 1
+		}
+
+		values := map[string]float64{
+			"xsoar_integration_incidents_pulled":   float64(health.IncidentsPulled),
+			"xsoar_integration_indicators_pulled":  float64(health.IndicatorsPulled),
+			"xsoar_integration_events_pulled":      float64(health.EventsPulled),
+			"xsoar_integration_incidents_dropped":  float64(health.IncidentsDropped),
+			"xsoar_integration_fetch_duration":     float64(health.FetchDuration),
+			"xsoar_integration_ingestion_duration": float64(health.LastIngestionDuration),
+			"xsoar_integration_error":              errored,
+		}
+		if !health.LastPullTime.IsZero() {
+			values["xsoar_integration_last_pull_timestamp"] = float64(health.LastPullTime.Unix())
+		}
+
+		for metric, value := range values {
+			metrics = append(metrics, HealthMetric{Instance: name, Brand: health.Brand, Name: metric, Value: value})
+		}
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Name != metrics[j].Name {
+			return metrics[i].Name < metrics[j].Name
+		}
+		return metrics[i].Instance < metrics[j].Instance
+	})
+
+	return metrics
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WritePrometheus writes the snapshot in the Prometheus text exposition
+// format.
+func (h *HealthMonitor) WritePrometheus(w io.Writer) error {
+	var last string
+	for _, metric := range h.Snapshot() {
+		if metric.Name != last {
+			if _, err := fmt.Fprintf(w, "# TYPE %s gauge\n", metric.Name); err != nil {
+				return err
+			}
+			last = metric.Name
+		}
+
+		_, err := fmt.Fprintf(w, "%s{instance=\"%s\",brand=\"%s\"} %g\n",
+			metric.Name, labelEscaper.Replace(metric.Instance), labelEscaper.Replace(metric.Brand), metric.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type IncidentFieldType string
+
+const (
+	ShortTextFieldType    IncidentFieldType = "shortText"
+	LongTextFieldType     IncidentFieldType = "longText"
+	BooleanFieldType      IncidentFieldType = "boolean"
+	NumberFieldType       IncidentFieldType = "number"
+	DateFieldType         IncidentFieldType = "date"
+	SingleSelectFieldType IncidentFieldType = "singleSelect"
+	MultiSelectFieldType  IncidentFieldType = "multiSelect"
+	TagsSelectFieldType   IncidentFieldType = "tagsSelect"
+	UserFieldType         IncidentFieldType = "user"
+	RoleFieldType         IncidentFieldType = "role"
+	GridFieldType         IncidentFieldType = "grid"
+	URLFieldType          IncidentFieldType = "url"
+	MarkdownFieldType     IncidentFieldType = "markdown"
+	HTMLFieldType         IncidentFieldType = "html"
+	AttachmentsFieldType  IncidentFieldType = "attachments"
+	TimerFieldType        IncidentFieldType = "timer"
+)
+
+type IncidentField struct {
+	ID                    string            `json:"id"`
+	Version               int               `json:"version"`
+	CacheVersn            int               `json:"cacheVersn"`
+	Modified              time.Time         `json:"modified"`
+	Created               time.Time         `json:"created"`
+	SizeInBytes           int               `json:"sizeInBytes"`
+	PackID                string            `json:"packID"`
+	PackName              string            `json:"packName"`
+	ItemVersion           string            `json:"itemVersion"`
+	FromServerVersion     string            `json:"fromServerVersion"`
+	ToServerVersion       string            `json:"toServerVersion"`
+	PropagationLabels     []string          `json:"propagationLabels"`
+	DefinitionId          string            `json:"definitionId"`
+	Name                  string            `json:"name"`
+	PrevName              string            `json:"prevName"`
+	CliName               string            `json:"cliName"`
+	Type                  IncidentFieldType `json:"type"`
+	Description           string            `json:"description"`
+	Placeholder           string            `json:"placeholder"`
+	Content               bool              `json:"content"`
+	Group                 int               `json:"group"`
+	System                bool              `json:"system"`
+	Locked                bool              `json:"locked"`
+	Required              bool              `json:"required"`
+	Unsearchable          bool              `json:"unsearchable"`
+	Hidden                bool              `json:"hidden"`
+	SelectValues          []string          `json:"selectValues"`
+	AssociatedTypes       []string          `json:"associatedTypes"`
+	AssociatedToAll       bool              `json:"associatedToAll"`
+	SystemAssociatedTypes []string          `json:"systemAssociatedTypes"`
+	DefaultRows           []map[string]any  `json:"defaultRows"`
+	Columns               []map[string]any  `json:"columns"`
+	Script                string            `json:"script"`
+	FieldCalcScript       string            `json:"fieldCalcScript"`
+	Threshold             int               `json:"threshold"`
+	Sla                   int               `json:"sla"`
+	CaseInsensitive       bool              `json:"caseInsensitive"`
+	OpenEnded             bool              `json:"openEnded"`
+	BreachScript          string            `json:"breachScript"`
+	RunScriptAfterUpdate  bool              `json:"runScriptAfterUpdate"`
+	EditForm              bool              `json:"editForm"`
+	NeverSetAsRequired    bool              `json:"neverSetAsRequired"`
+	IsReadOnly            bool              `json:"isReadOnly"`
+	SortValues            []string          `json:"sortValues"`
+}
+
+type IncidentFieldUpsert struct {
+	ID                   string            `json:"id,omitempty"`
+	Version              int               `json:"version,omitempty"`
+	Name                 string            `json:"name"`
+	CliName              string            `json:"cliName"`
+	Type                 IncidentFieldType `json:"type"`
+	Description          string            `json:"description,omitempty"`
+	Placeholder          string            `json:"placeholder,omitempty"`
+	Content              bool              `json:"content"`
+	Group                int               `json:"group"`
+	Required             bool              `json:"required"`
+	Unsearchable         bool              `json:"unsearchable"`
+	SelectValues         []string          `json:"selectValues,omitempty"`
+	AssociatedTypes      []string          `json:"associatedTypes,omitempty"`
+	AssociatedToAll      bool              `json:"associatedToAll"`
+	Script               string            `json:"script,omitempty"`
+	FieldCalcScript      string            `json:"fieldCalcScript,omitempty"`
+	Hidden               bool              `json:"hidden"`
+	Columns              []map[string]any  `json:"columns,omitempty"`
+	DefaultRows          []map[string]any  `json:"defaultRows,omitempty"`
+	Threshold            int               `json:"threshold"`
+	Sla                  int               `json:"sla"`
+	CaseInsensitive      bool              `json:"caseInsensitive"`
+	OpenEnded            bool              `json:"openEnded"`
+	EditForm             bool              `json:"editForm"`
+	RunScriptAfterUpdate bool              `json:"runScriptAfterUpdate"`
+	BreachScript         string            `json:"breachScript,omitempty"`
+	IsReadOnly           bool              `json:"isReadOnly"`
+}
+
+func (f IncidentField) ToUpsert() IncidentFieldUpsert {
+	return IncidentFieldUpsert{
+		ID:                   f.ID,
+		Version:              f.Version,
+		Name:                 f.Name,
+		CliName:              f.CliName,
+		Type:                 f.Type,
+		Description:          f.Description,
+		Placeholder:          f.Placeholder,
+		Content:              f.Content,
+		Group:                f.Group,
+		Required:             f.Required,
+		Unsearchable:         f.Unsearchable,
+		SelectValues:         f.SelectValues,
+		AssociatedTypes:      f.AssociatedTypes,
+		AssociatedToAll:      f.AssociatedToAll,
+		Script:               f.Script,
+		FieldCalcScript:      f.FieldCalcScript,
+		Hidden:               f.Hidden,
+		Columns:              f.Columns,
+		DefaultRows:          f.DefaultRows,
+		Threshold:            f.Threshold,
+		Sla:                  f.Sla,
+		CaseInsensitive:      f.CaseInsensitive,
+		OpenEnded:            f.OpenEnded,
+		EditForm:             f.EditForm,
+		RunScriptAfterUpdate: f.RunScriptAfterUpdate,
+		BreachScript:         f.BreachScript,
+		IsReadOnly:           f.IsReadOnly,
+	}
+}
+
+type incidentFieldsExport struct {
+	IncidentFields []IncidentField `json:"incidentFields"`
+}
+
+type IncidentFieldModule struct {
+	client *Client
+}
+
+func (m *IncidentFieldModule) ListFields() ([]IncidentField, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "incidentfields",
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode[[]IncidentField](resp)
+}
+
+func (m *IncidentFieldModule) GetField(id string) (IncidentField, error) {
+	fields, err := m.ListFields()
+	if err != nil {
+		return IncidentField{}, err
+	}
+
+	for _, field := range fields {
+		if field.ID == id || field.CliName == id {
+			return field, nil
+		}
+	}
+
+	return IncidentField{}, errors.Errorf("incident field %q not found", id)
+}
+
+func (m *IncidentFieldModule) UpsertField(f IncidentFieldUpsert) (IncidentField, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(f); err != nil {
+		return IncidentField{}, err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "incidentfield",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return IncidentField{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return IncidentField{}, err
+	}
+
+	return Decode[IncidentField](resp)
+}
+
+func (m *IncidentFieldModule) DeleteField(id string) error {
+	req, err := m.client.NewRequest(
+		http.MethodDelete, "incidentfield/"+id,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func (m *IncidentFieldModule) ImportFields(filename string, content io.Reader) ([]IncidentField, error) {
+	req, err := m.client.NewRequest(
+		http.MethodPost, "incidentfields/import",
+		WithFile("file", filename, content),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode[[]IncidentField](resp)
+}
+
+// ParseIncidentFieldsExport reads the fields of an incident fields export,
+// either the `incidentFields` document or a single field definition.
+func ParseIncidentFieldsExport(r io.Reader) ([]IncidentField, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var export incidentFieldsExport
+	if err := json.Unmarshal(data, &export); err == nil && export.IncidentFields != nil {
+		return export.IncidentFields, nil
+	}
+
+	var field IncidentField
+	if err := json.Unmarshal(data, &field); err != nil {
+		return nil, err
+	}
+
+	return []IncidentField{field}, nil
+}
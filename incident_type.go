@@ -0,0 +1,214 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type IncidentType struct {
+	ID                  string    `json:"id"`
+	Version             int       `json:"version"`
+	CacheVersn          int       `json:"cacheVersn"`
+	Modified            time.Time `json:"modified"`
+	Created             time.Time `json:"created"`
+	SizeInBytes         int       `json:"sizeInBytes"`
+	PackID              string    `json:"packID"`
+	PackName            string    `json:"packName"`
+	ItemVersion         string    `json:"itemVersion"`
+	FromServerVersion   string    `json:"fromServerVersion"`
+	ToServerVersion     string    `json:"toServerVersion"`
+	PropagationLabels   []string  `json:"propagationLabels"`
+	DefinitionId        string    `json:"definitionId"`
+	Name                string    `json:"name"`
+	PrevName            string    `json:"prevName"`
+	Color               string    `json:"color"`
+	Playbook            string    `json:"playbookId"`
+	Autorun             bool      `json:"autorun"`
+	PreProcessingScript string    `json:"preProcessingScript"`
+	ClosureScript       string    `json:"closureScript"`
+	Layout              string    `json:"layout"`
+	Hours               int       `json:"hours"`
+	Days                int       `json:"days"`
+	Weeks               int       `json:"weeks"`
+	HoursR              int       `json:"hoursR"`
+	DaysR               int       `json:"daysR"`
+	WeeksR              int       `json:"weeksR"`
+	ReputationCalc      int       `json:"reputationCalc"`
+	OnChangeRepAlg      int       `json:"onChangeRepAlg"`
+	System              bool      `json:"system"`
+	Locked              bool      `json:"locked"`
+	Disabled            bool      `json:"disabled"`
+	Readonly            bool      `json:"readonly"`
+	Extractor           any       `json:"extractSettings"`
+	SortValues          []string  `json:"sortValues"`
+}
+
+type IncidentTypeUpsert struct {
+	ID                  string `json:"id,omitempty"`
+	Version             int    `json:"version,omitempty"`
+	Name                string `json:"name"`
+	Color               string `json:"color,omitempty"`
+	Playbook            string `json:"playbookId,omitempty"`
+	Autorun             bool   `json:"autorun"`
+	PreProcessingScript string `json:"preProcessingScript,omitempty"`
+	ClosureScript       string `json:"closureScript,omitempty"`
+	Layout              string `json:"layout,omitempty"`
+	Hours               int    `json:"hours"`
+	Days                int    `json:"days"`
+	Weeks               int    `json:"weeks"`
+	HoursR              int    `json:"hoursR"`
+	DaysR               int    `json:"daysR"`
+	WeeksR              int    `json:"weeksR"`
+	ReputationCalc      int    `json:"reputationCalc"`
+	OnChangeRepAlg      int    `json:"onChangeRepAlg"`
+	Disabled            bool   `json:"disabled"`
+	Extractor           any    `json:"extractSettings,omitempty"`
+}
+
+type IncidentTypeModule struct {
+	client *Client
+}
+
+func (t IncidentType) ToUpsert() IncidentTypeUpsert {
+	return IncidentTypeUpsert{
+		ID:                  t.ID,
+		Version:             t.Version,
+		Name:                t.Name,
+		Color:               t.Color,
+		Playbook:            t.Playbook,
+		Autorun:             t.Autorun,
+		PreProcessingScript: t.PreProcessingScript,
+		ClosureScript:       t.ClosureScript,
+		Layout:              t.Layout,
+		Hours:               t.Hours,
+		Days:                t.Days,
+		Weeks:               t.Weeks,
+		HoursR:              t.HoursR,
+		DaysR:               t.DaysR,
+		WeeksR:              t.WeeksR,
+		ReputationCalc:      t.ReputationCalc,
+		OnChangeRepAlg:      t.OnChangeRepAlg,
+		Disabled:            t.Disabled,
+		Extractor:           t.Extractor,
+	}
+}
+
+func (m *IncidentTypeModule) ListTypes() ([]IncidentType, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "incidenttype",
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode[[]IncidentType](resp)
+}
+
+func (m *IncidentTypeModule) GetType(id string) (IncidentType, error) {
+	types, err := m.ListTypes()
+	if err != nil {
+		return IncidentType{}, err
+	}
+
+	for _, t := range types {
+		if t.ID == id || t.Name == id {
+			return t, nil
+		}
+	}
+
+	return IncidentType{}, errors.Errorf("incident type %q not found", id)
+}
+
+func (m *IncidentTypeModule) UpsertType(t IncidentTypeUpsert) (IncidentType, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(t); err != nil {
+		return IncidentType{}, err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "incidenttype",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return IncidentType{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return IncidentType{}, err
+	}
+
+	return Decode[IncidentType](resp)
+}
+
+func (m *IncidentTypeModule) DeleteType(id string) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string]string{"id": id}); err != nil {
+		return err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "incidenttype/delete",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func (m *IncidentTypeModule) ImportTypes(filename string, content io.Reader) ([]IncidentType, error) {
+	req, err := m.client.NewRequest(
+		http.MethodPost, "incidenttype/import",
+		WithFile("file", filename, content),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode[[]IncidentType](resp)
+}
+
+// ParseIncidentTypesExport reads the types of an incident types export,
+// either a list of types or a single type definition.
+func ParseIncidentTypesExport(r io.Reader) ([]IncidentType, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []IncidentType
+	if err := json.Unmarshal(data, &types); err == nil {
+		return types, nil
+	}
+
+	var t IncidentType
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+
+	return []IncidentType{t}, nil
+}
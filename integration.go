@@ -12,15 +12,36 @@ import (
 type IntegrationParamType int
 
 const (
-	AuthenticationParamType IntegrationParamType = 9
-	BoolParamType           IntegrationParamType = 8
-	EncryptedParamType      IntegrationParamType = 4
-	LongTextParamType       IntegrationParamType = 12
-	MultiSelectParamType    IntegrationParamType = 16
-	ShortTextParamType      IntegrationParamType = 0
-	SingleSelectParamType   IntegrationParamType = 15
+	ShortTextParamType         IntegrationParamType = 0
+	NumberParamType            IntegrationParamType = 1
+	EncryptedParamType         IntegrationParamType = 4
+	BoolParamType              IntegrationParamType = 8
+	AuthenticationParamType    IntegrationParamType = 9
+	DownloadLinkParamType      IntegrationParamType = 11
+	LongTextParamType          IntegrationParamType = 12
+	IncidentTypeParamType      IntegrationParamType = 13
+	EncryptedLongTextParamType IntegrationParamType = 14
+	SingleSelectParamType      IntegrationParamType = 15
+	MultiSelectParamType       IntegrationParamType = 16
+	ExpirationParamType        IntegrationParamType = 17
+	FeedReputationParamType    IntegrationParamType = 18
+	IntervalParamType          IntegrationParamType = 19
 )
 
+type IntegrationLogLevel string
+
+const (
+	LogLevelOff     IntegrationLogLevel = "off"
+	LogLevelDebug   IntegrationLogLevel = "debug"
+	LogLevelVerbose IntegrationLogLevel = "verbose"
+)
+
+// IsOff reports whether the level disables logging, instances that were
+// never configured have an empty level.
+func (l IntegrationLogLevel) IsOff() bool {
+	return l == "" || l == LogLevelOff
+}
+
 type InstanceIntegrationData struct {
 	Section         string               `json:"section"`
 	Advanced        bool                 `json:"advanced"`
@@ -83,7 +104,7 @@ type IntegrationInstance struct {
 	IsSystemIntegration   bool                             `json:"isSystemIntegration"`
 	CanSample             bool                             `json:"canSample"`
 	DefaultIgnore         bool                             `json:"defaultIgnore"`
-	IntegrationLogLevel   string                           `json:"integrationLogLevel"`
+	IntegrationLogLevel   IntegrationLogLevel              `json:"integrationLogLevel"`
 	CommandsPermissions   map[string]IntegrationPermission `json:"commandsPermissions"`
 	LongRunningId         string                           `json:"longRunningId"`
 	IncidentFetchInterval int                              `json:"incidentFetchInterval"`
@@ -202,12 +223,13 @@ type Integration struct {
 	DefaultClassifier                 string                    `json:"defaultMapperIn"`
 }
 
+// Engines is returned by both the engines search and the integrations search.
 type Engines struct {
-	EngineGroups  any      `json:"engineGroups"`
-	Engines       []any    `json:"engines"`
-	PkgTypes      []string `json:"pkgTypes"`
-	RequestedLogs any      `json:"requestedLogs"`
-	Total         int      `json:"total"`
+	EngineGroups  []EngineGroup `json:"engineGroups"`
+	Engines       []Engine      `json:"engines"`
+	PkgTypes      []string      `json:"pkgTypes"`
+	RequestedLogs any           `json:"requestedLogs"`
+	Total         int           `json:"total"`
 }
 
 type InstanceHealth struct {
@@ -260,25 +282,27 @@ type InstanceIntegrationDataUpsert struct {
 }
 
 type IntegrationInstanceUpsert struct {
-	ID                  string                          `json:"id,omitempty"`
-	Name                string                          `json:"name"`
-	Brand               string                          `json:"brand"`
-	Version             int                             `json:"version"`
-	Enabled             bool                            `json:"enabled,string"`
-	ConfigValues        map[string]any                  `json:"configvalues,omitempty"`
-	Engine              string                          `json:"engine"`
-	EngineGroup         string                          `json:"engineGroup"`
-	Hidden              bool                            `json:"hidden"`
-	IsIntegrationScript bool                            `json:"isIntegrationScript"`
-	MappingId           string                          `json:"mappingId"`
-	OutgoingMapperId    string                          `json:"outgoingMapperId"`
-	IncomingMapperId    string                          `json:"incomingMapperId"`
-	CanSample           bool                            `json:"canSample"`
-	IntegrationLogLevel string                          `json:"integrationLogLevel"`
-	PropagationLabels   []string                        `json:"PropagationLabels"`
-	DefaultIgnore       bool                            `json:"defaultIgnore"`
-	Data                []InstanceIntegrationDataUpsert `json:"data,omitempty"`
-	// CommandsPermissions   map[string]IntegrationPermission `json:"commandsPermissions"`
+	ID                  string                           `json:"id,omitempty"`
+	Name                string                           `json:"name"`
+	PrevName            string                           `json:"prevName,omitempty"`
+	Brand               string                           `json:"brand"`
+	Version             int                              `json:"version"`
+	Enabled             bool                             `json:"enabled,string"`
+	ConfigValues        map[string]any                   `json:"configvalues,omitempty"`
+	Engine              string                           `json:"engine"`
+	EngineGroup         string                           `json:"engineGroup"`
+	Hidden              bool                             `json:"hidden"`
+	IsIntegrationScript bool                             `json:"isIntegrationScript"`
+	MappingId           string                           `json:"mappingId"`
+	OutgoingMapperId    string                           `json:"outgoingMapperId"`
+	IncomingMapperId    string                           `json:"incomingMapperId"`
+	CanSample           bool                             `json:"canSample"`
+	IntegrationLogLevel IntegrationLogLevel              `json:"integrationLogLevel"`
+	PropagationLabels   []string                         `json:"PropagationLabels"`
+	DefaultIgnore       bool                             `json:"defaultIgnore"`
+	Data                []InstanceIntegrationDataUpsert  `json:"data,omitempty"`
+	CommandsPermissions map[string]IntegrationPermission `json:"commandsPermissions,omitempty"`
+	DebugMode           bool                             `json:"debugMode"`
 }
 
 type SearchIntegrationsOptions struct {
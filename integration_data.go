@@ -0,0 +1,227 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type InstanceCredentials struct {
+	Credential      string `json:"credential"`
+	Identifier      string `json:"identifier"`
+	Password        string `json:"password"`
+	PasswordChanged bool   `json:"passwordChanged"`
+}
+
+func (d InstanceIntegrationData) isEmpty() bool {
+	raw := bytes.TrimSpace(d.Value)
+	return len(raw) == 0 || bytes.Equal(raw, []byte("null"))
+}
+
+func (d InstanceIntegrationData) checkType(types ...IntegrationParamType) error {
+	for _, t := range types {
+		if d.Type == t {
+			return nil
+		}
+	}
+	return errors.Errorf("param %q has type %d, expected one of %v", d.Name, d.Type, types)
+}
+
+func (d InstanceIntegrationData) StringValue() (string, error) {
+	if err := d.checkType(
+		ShortTextParamType, NumberParamType, EncryptedParamType, DownloadLinkParamType,
+		LongTextParamType, IncidentTypeParamType, EncryptedLongTextParamType,
+		SingleSelectParamType, ExpirationParamType, FeedReputationParamType, IntervalParamType,
+	); err != nil {
+		return "", err
+	}
+
+	if d.isEmpty() {
+		return "", nil
+	}
+
+	var str string
+	if err := json.Unmarshal(d.Value, &str); err != nil {
+		// numbers and intervals may be stored unquoted
+		return string(bytes.TrimSpace(d.Value)), nil
+	}
+
+	return str, nil
+}
+
+func (d InstanceIntegrationData) BoolValue() (bool, error) {
+	if err := d.checkType(BoolParamType); err != nil {
+		return false, err
+	}
+
+	if d.isEmpty() {
+		return false, nil
+	}
+
+	var value any
+	if err := json.Unmarshal(d.Value, &value); err != nil {
+		return false, errors.Wrapf(err, "param %q", d.Name)
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if v == "" {
+			return false, nil
+		}
+		b, err := strconv.ParseBool(v)
+		return b, errors.Wrapf(err, "param %q", d.Name)
+	default:
+		return false, errors.Errorf("param %q has invalid boolean value: %s", d.Name, d.Value)
+	}
+}
+
+func (d InstanceIntegrationData) MultiSelectValue() ([]string, error) {
+	if err := d.checkType(MultiSelectParamType); err != nil {
+		return nil, err
+	}
+
+	if d.isEmpty() {
+		return nil, nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(d.Value, &values); err == nil {
+		return values, nil
+	}
+
+	var str string
+	if err := json.Unmarshal(d.Value, &str); err != nil {
+		return nil, errors.Errorf("param %q has invalid multi-select value: %s", d.Name, d.Value)
+	}
+
+	if str == "" {
+		return nil, nil
+	}
+
+	return strings.Split(str, ","), nil
+}
+
+func (d InstanceIntegrationData) CredentialsValue() (InstanceCredentials, error) {
+	if err := d.checkType(AuthenticationParamType); err != nil {
+		return InstanceCredentials{}, err
+	}
+
+	if d.isEmpty() {
+		return InstanceCredentials{}, nil
+	}
+
+	var creds InstanceCredentials
+	if err := json.Unmarshal(d.Value, &creds); err != nil {
+		return InstanceCredentials{}, errors.Wrapf(err, "param %q", d.Name)
+	}
+
+	return creds, nil
+}
+
+// DecodedValue returns the value using the getter matching the param type,
+// values of unknown types are returned as raw JSON.
+func (d InstanceIntegrationData) DecodedValue() (any, error) {
+	switch d.Type {
+	case BoolParamType:
+		return d.BoolValue()
+	case MultiSelectParamType:
+		return d.MultiSelectValue()
+	case AuthenticationParamType:
+		return d.CredentialsValue()
+	case ShortTextParamType, NumberParamType, EncryptedParamType, DownloadLinkParamType,
+		LongTextParamType, IncidentTypeParamType, EncryptedLongTextParamType,
+		SingleSelectParamType, ExpirationParamType, FeedReputationParamType, IntervalParamType:
+		return d.StringValue()
+	default:
+		if d.isEmpty() {
+			return nil, nil
+		}
+		return d.Value, nil
+	}
+}
+
+func (i IntegrationInstance) Param(name string) (InstanceIntegrationData, bool) {
+	for _, d := range i.Data {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return InstanceIntegrationData{}, false
+}
+
+func (i IntegrationInstance) ToUpsert() (IntegrationInstanceUpsert, error) {
+	upsert := IntegrationInstanceUpsert{
+		ID:                  i.ID,
+		Name:                i.Name,
+		PrevName:            i.Name,
+		Brand:               i.Brand,
+		Version:             i.Version,
+		Enabled:             i.Enabled,
+		ConfigValues:        i.ConfigValues,
+		Engine:              i.Engine,
+		EngineGroup:         i.EngineGroup,
+		Hidden:              i.Hidden,
+		IsIntegrationScript: i.IsIntegrationScript,
+		MappingId:           i.MappingId,
+		OutgoingMapperId:    i.OutgoingMapperId,
+		IncomingMapperId:    i.IncomingMapperId,
+		CanSample:           i.CanSample,
+		IntegrationLogLevel: i.IntegrationLogLevel,
+		PropagationLabels:   i.PropagationLabels,
+		DefaultIgnore:       i.DefaultIgnore,
+		CommandsPermissions: i.CommandsPermissions,
+		DebugMode:           i.DebugMode,
+	}
+
+	for _, d := range i.Data {
+		value, err := d.DecodedValue()
+		if err != nil {
+			return IntegrationInstanceUpsert{}, err
+		}
+
+		upsert.Data = append(upsert.Data, InstanceIntegrationDataUpsert{
+			Name:     d.Name,
+			Type:     d.Type,
+			Value:    value,
+			Hasvalue: d.Hasvalue,
+		})
+	}
+
+	return upsert, nil
+}
+
+// UnmarshalJSON accepts enabled as a boolean as well as the string sent to
+// the server, so that upserts can be read from user documents.
+func (u *IntegrationInstanceUpsert) UnmarshalJSON(data []byte) error {
+	type upsert IntegrationInstanceUpsert
+	var aux struct {
+		upsert
+		Enabled any `json:"enabled"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*u = IntegrationInstanceUpsert(aux.upsert)
+
+	switch v := aux.Enabled.(type) {
+	case nil:
+	case bool:
+		u.Enabled = v
+	case string:
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.Wrap(err, "enabled")
+		}
+		u.Enabled = enabled
+	default:
+		return errors.Errorf("invalid enabled value %v", v)
+	}
+
+	return nil
+}
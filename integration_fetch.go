@@ -0,0 +1,63 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+type LastRun struct {
+	Instance string         `json:"instance"`
+	Brand    string         `json:"brand"`
+	Value    map[string]any `json:"value"`
+}
+
+type instanceName struct {
+	Name string `json:"name"`
+}
+
+func (m *IntegrationModule) GetLastRun(instance string) (LastRun, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "settings/integration/lastrun/"+url.PathEscape(instance),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return LastRun{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return LastRun{}, err
+	}
+
+	return Decode[LastRun](resp)
+}
+
+func (m *IntegrationModule) postInstanceName(endpoint, instance string) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(instanceName{instance}); err != nil {
+		return err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, endpoint,
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func (m *IntegrationModule) ResetLastRun(instance string) error {
+	return m.postInstanceName("settings/integration/lastrun/reset", instance)
+}
+
+func (m *IntegrationModule) TriggerFetch(instance string) error {
+	return m.postInstanceName("settings/integration/fetch", instance)
+}
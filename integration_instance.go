@@ -0,0 +1,225 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type InstanceOverride func(*IntegrationInstanceUpsert) error
+
+func WithInstanceParam(name string, value any) InstanceOverride {
+	return func(u *IntegrationInstanceUpsert) error {
+		for i := range u.Data {
+			if u.Data[i].Name == name {
+				u.Data[i].Value = value
+				u.Data[i].Hasvalue = true
+				return nil
+			}
+		}
+		return errors.Errorf("param %q not found on instance %q", name, u.Name)
+	}
+}
+
+func WithInstanceEngine(engine string) InstanceOverride {
+	return func(u *IntegrationInstanceUpsert) error {
+		u.Engine, u.EngineGroup = engine, ""
+		return nil
+	}
+}
+
+func WithInstanceEngineGroup(engineGroup string) InstanceOverride {
+	return func(u *IntegrationInstanceUpsert) error {
+		u.Engine, u.EngineGroup = "", engineGroup
+		return nil
+	}
+}
+
+func WithInstanceEnabled(enabled bool) InstanceOverride {
+	return func(u *IntegrationInstanceUpsert) error {
+		u.Enabled = enabled
+		return nil
+	}
+}
+
+func (m *IntegrationModule) GetInstance(id string) (IntegrationInstance, error) {
+	instances, err := m.GetInstances()
+	if err != nil {
+		return IntegrationInstance{}, err
+	}
+
+	for _, instance := range instances {
+		if instance.ID == id {
+			return instance, nil
+		}
+	}
+
+	return IntegrationInstance{}, errors.Errorf("instance %q not found", id)
+}
+
+// storedSecret reports whether the param holds a secret stored by the server,
+// which is returned masked. Authentication params referencing a credential
+// hold no secret of their own.
+func storedSecret(d InstanceIntegrationDataUpsert) bool {
+	if !d.Hasvalue {
+		return false
+	}
+
+	switch d.Type {
+	case EncryptedParamType, EncryptedLongTextParamType:
+		return true
+	case AuthenticationParamType:
+		creds, ok := d.Value.(InstanceCredentials)
+		return ok && creds.Credential == "" && creds.Password != ""
+	default:
+		return false
+	}
+}
+
+// KeepSecrets makes the server retain the stored secrets of the instance
+// instead of overwriting them with the masked values it returned. Encrypted
+// params are sent back with their masked value, which the server resolves to
+// the stored one for the same instance ID, and authentication params are
+// flagged as unchanged.
+func (u *IntegrationInstanceUpsert) KeepSecrets() {
+	for i, d := range u.Data {
+		switch d.Type {
+		case EncryptedParamType, EncryptedLongTextParamType:
+			// keep the masked value as returned by the server
+		case AuthenticationParamType:
+			if creds, ok := d.Value.(InstanceCredentials); ok {
+				creds.PasswordChanged = false
+				u.Data[i].Value = creds
+			}
+		}
+	}
+}
+
+func (m *IntegrationModule) updateInstance(id string, overrides ...InstanceOverride) (IntegrationInstance, error) {
+	instance, err := m.GetInstance(id)
+	if err != nil {
+		return IntegrationInstance{}, err
+	}
+
+	upsert, err := instance.ToUpsert()
+	if err != nil {
+		return IntegrationInstance{}, err
+	}
+
+	upsert.KeepSecrets()
+
+	for _, fn := range overrides {
+		if err := fn(&upsert); err != nil {
+			return IntegrationInstance{}, err
+		}
+	}
+
+	return m.UpsertInstance(upsert)
+}
+
+// CloneInstance creates a copy of the instance under a new name. The stored
+// secrets cannot be copied as the server only keeps them for the same instance
+// ID, so every encrypted or authentication param with a value must be given
+// through WithInstanceParam.
+func (m *IntegrationModule) CloneInstance(id, newName string, overrides ...InstanceOverride) (IntegrationInstance, error) {
+	var secrets []string
+
+	clone := func(u *IntegrationInstanceUpsert) error {
+		u.ID, u.PrevName, u.Name, u.Version = "", "", newName, 0
+
+		for i, d := range u.Data {
+			if storedSecret(d) {
+				secrets = append(secrets, d.Name)
+				u.Data[i].Value = nil
+			}
+		}
+
+		return nil
+	}
+
+	requireSecrets := func(u *IntegrationInstanceUpsert) error {
+		var missing []string
+		for _, d := range u.Data {
+			if slices.Contains(secrets, d.Name) && d.Value == nil {
+				missing = append(missing, d.Name)
+			}
+		}
+
+		if len(missing) > 0 {
+			return errors.Errorf("secret params %s of instance %q must be overridden to clone it", strings.Join(missing, ", "), id)
+		}
+
+		return nil
+	}
+
+	overrides = append([]InstanceOverride{clone}, overrides...)
+	return m.updateInstance(id, append(overrides, requireSecrets)...)
+}
+
+func (m *IntegrationModule) RenameInstance(id, newName string) (IntegrationInstance, error) {
+	return m.updateInstance(id, func(u *IntegrationInstanceUpsert) error {
+		u.Name = newName
+		return nil
+	})
+}
+
+func (m *IntegrationModule) MoveInstanceToEngine(id, engine string) (IntegrationInstance, error) {
+	return m.updateInstance(id, WithInstanceEngine(engine))
+}
+
+func (m *IntegrationModule) MoveInstanceToEngineGroup(id, engineGroup string) (IntegrationInstance, error) {
+	return m.updateInstance(id, WithInstanceEngineGroup(engineGroup))
+}
+
+func (m *IntegrationModule) MoveInstanceToEngineByName(id, engineName string) (IntegrationInstance, error) {
+	engine, err := m.client.Engine.GetEngineByName(engineName)
+	if err != nil {
+		return IntegrationInstance{}, err
+	}
+
+	return m.MoveInstanceToEngine(id, engine.ID)
+}
+
+func (m *IntegrationModule) MoveInstanceToEngineGroupByName(id, groupName string) (IntegrationInstance, error) {
+	group, err := m.client.Engine.GetEngineGroupByName(groupName)
+	if err != nil {
+		return IntegrationInstance{}, err
+	}
+
+	return m.MoveInstanceToEngineGroup(id, group.ID)
+}
+
+type InstanceTestResult struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func (m *IntegrationModule) TestInstance(instance IntegrationInstanceUpsert) (InstanceTestResult, error) {
+	instance.IsIntegrationScript = true
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(instance); err != nil {
+		return InstanceTestResult{}, err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "settings/integration/test",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return InstanceTestResult{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return InstanceTestResult{}, err
+	}
+
+	return Decode[InstanceTestResult](resp)
+}
@@ -0,0 +1,68 @@
+package xsoar
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+func (m *IntegrationModule) SetInstanceLogLevel(id string, level IntegrationLogLevel) (IntegrationInstance, error) {
+	return m.updateInstance(id, func(u *IntegrationInstanceUpsert) error {
+		u.IntegrationLogLevel = level
+		u.DebugMode = !level.IsOff()
+		return nil
+	})
+}
+
+// DebugInstanceFor sets the instance log level for the given duration then
+// reverts it to its previous value. It blocks until the level is reverted,
+// which also happens when ctx is cancelled.
+func (m *IntegrationModule) DebugInstanceFor(ctx context.Context, id string, level IntegrationLogLevel, d time.Duration) error {
+	instance, err := m.GetInstance(id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.SetInstanceLogLevel(id, level); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	_, err = m.updateInstance(id, func(u *IntegrationInstanceUpsert) error {
+		u.IntegrationLogLevel = instance.IntegrationLogLevel
+		u.DebugMode = instance.DebugMode
+		return nil
+	})
+	return err
+}
+
+// DownloadInstanceLogs returns the log bundle of an instance as a zip
+// stream, the caller is responsible for closing it.
+func (m *IntegrationModule) DownloadInstanceLogs(id string) (io.ReadCloser, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "log/bundle",
+		WithHeader("Accept", "application/octet-stream"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Set("instanceId", id)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,157 @@
+package xsoar
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+type CommandPermissionEntry struct {
+	InstanceID   string   `json:"instanceId"`
+	InstanceName string   `json:"instanceName"`
+	Brand        string   `json:"brand"`
+	Command      string   `json:"command"`
+	Roles        []string `json:"roles"`
+
+	// AllRoles is set when the command is not restricted to any role
+	AllRoles bool `json:"allRoles"`
+}
+
+func (m *IntegrationModule) GetCommandPermissions(instanceID string) (map[string]IntegrationPermission, error) {
+	instance, err := m.GetInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return instance.CommandsPermissions, nil
+}
+
+func (m *IntegrationModule) validateRoles(roles ...string) error {
+	existing, err := m.client.Role.GetRoles()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(existing))
+	for _, r := range existing {
+		names = append(names, r.Name)
+	}
+
+	for _, role := range roles {
+		if !slices.Contains(names, role) {
+			return errors.Errorf("role %q does not exist", role)
+		}
+	}
+
+	return nil
+}
+
+// brandCommands returns the command names of each integration brand.
+func (m *IntegrationModule) brandCommands() (map[string][]string, error) {
+	integrations, err := m.GetIntegrationCommands()
+	if err != nil {
+		return nil, err
+	}
+
+	commands := make(map[string][]string, len(integrations))
+	for _, integration := range integrations {
+		var names []string
+		for _, command := range integration.Commands {
+			names = append(names, command.Name)
+		}
+
+		commands[integration.Name] = names
+		if integration.ID != integration.Name {
+			commands[integration.ID] = names
+		}
+	}
+
+	return commands, nil
+}
+
+// SetCommandPermissions restricts each command to the given roles. Commands
+// mapped to an empty slice are open to every role again.
+func (m *IntegrationModule) SetCommandPermissions(instanceID string, permissions map[string][]string) (IntegrationInstance, error) {
+	var roles []string
+	for _, r := range permissions {
+		roles = append(roles, r...)
+	}
+
+	if err := m.validateRoles(roles...); err != nil {
+		return IntegrationInstance{}, err
+	}
+
+	commands, err := m.brandCommands()
+	if err != nil {
+		return IntegrationInstance{}, err
+	}
+
+	return m.updateInstance(instanceID, func(u *IntegrationInstanceUpsert) error {
+		for command := range permissions {
+			if !slices.Contains(commands[u.Brand], command) {
+				return errors.Errorf("integration %q has no command %q", u.Brand, command)
+			}
+		}
+
+		if u.CommandsPermissions == nil {
+			u.CommandsPermissions = make(map[string]IntegrationPermission)
+		}
+
+		for command, r := range permissions {
+			previous := u.CommandsPermissions[command]
+			u.CommandsPermissions[command] = IntegrationPermission{
+				Roles:         r,
+				PreviousRoles: previous.Roles,
+				HasRole:       len(r) > 0,
+			}
+		}
+
+		return nil
+	})
+}
+
+// CommandPermissionsReport lists every command of every instance with the
+// roles allowed to run it.
+func (m *IntegrationModule) CommandPermissionsReport() ([]CommandPermissionEntry, error) {
+	instances, err := m.GetInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := m.brandCommands()
+	if err != nil {
+		return nil, err
+	}
+
+	var report []CommandPermissionEntry
+	for _, instance := range instances {
+		names := slices.Clone(commands[instance.Brand])
+		for command := range instance.CommandsPermissions {
+			if !slices.Contains(names, command) {
+				names = append(names, command)
+			}
+		}
+
+		for _, command := range names {
+			roles := instance.CommandsPermissions[command].Roles
+			report = append(report, CommandPermissionEntry{
+				InstanceID:   instance.ID,
+				InstanceName: instance.Name,
+				Brand:        instance.Brand,
+				Command:      command,
+				Roles:        roles,
+				AllRoles:     len(roles) == 0,
+			})
+		}
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].InstanceName != report[j].InstanceName {
+			return report[i].InstanceName < report[j].InstanceName
+		}
+		return report[i].Command < report[j].Command
+	})
+
+	return report, nil
+}
@@ -0,0 +1,213 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TaskState string
+
+const (
+	TaskStateNotStarted     TaskState = ""
+	TaskStateWaiting        TaskState = "Waiting"
+	TaskStateInProgress     TaskState = "inprogress"
+	TaskStateCompleted      TaskState = "Completed"
+	TaskStateError          TaskState = "Error"
+	TaskStateLoopError      TaskState = "LoopError"
+	TaskStateWillNotExecute TaskState = "WillNotBeExecuted"
+	TaskStateBlocked        TaskState = "Blocked"
+)
+
+type InvestigationTask struct {
+	ID               string                 `json:"id"`
+	Type             PlaybookTaskType       `json:"type"`
+	State            TaskState              `json:"state"`
+	Task             PlaybookTaskDefinition `json:"task"`
+	NextTasks        map[string][]string    `json:"nextTasks"`
+	Conditions       []PlaybookCondition    `json:"conditions"`
+	ScriptArguments  map[string]any         `json:"scriptArguments"`
+	Assignee         string                 `json:"assignee"`
+	AssigneeSet      bool                   `json:"assigneeSet"`
+	StartDate        time.Time              `json:"startDate"`
+	CompletedDate    time.Time              `json:"completedDate"`
+	DueDate          time.Time              `json:"dueDate"`
+	CompletedBy      string                 `json:"completedBy"`
+	Entries          []string               `json:"entries"`
+	Note             bool                   `json:"note"`
+	ParentPlaybookID string                 `json:"parentPlaybookID"`
+	SubPlaybook      *InvestigationPlaybook `json:"subPlaybook,omitempty"`
+	Message          map[string]any         `json:"message"`
+	Outputs          map[string]any         `json:"outputs"`
+	Error            string                 `json:"error"`
+}
+
+type InvestigationPlaybook struct {
+	ID          string                       `json:"id"`
+	Version     int                          `json:"version"`
+	Name        string                       `json:"name"`
+	PlaybookID  string                       `json:"playbookId"`
+	State       TaskState                    `json:"state"`
+	StartDate   time.Time                    `json:"startDate"`
+	StartTaskID string                       `json:"startTaskId"`
+	Tasks       map[string]InvestigationTask `json:"tasks"`
+}
+
+type TaskCompletion struct {
+	InvestigationID string
+	TaskID          string
+	Answer          string
+	Comment         string
+	Version         int
+}
+
+type taskAction struct {
+	InvestigationID string `json:"investigationId"`
+	TaskID          string `json:"taskId"`
+	Assignee        string `json:"assignee,omitempty"`
+	Note            string `json:"note,omitempty"`
+}
+
+type InvestigationModule struct {
+	client *Client
+}
+
+func (m *InvestigationModule) GetPlaybook(investigationID string) (InvestigationPlaybook, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "inv-playbook/"+investigationID,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return InvestigationPlaybook{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return InvestigationPlaybook{}, err
+	}
+
+	return Decode[InvestigationPlaybook](resp)
+}
+
+// PlaybookRunTask is a task of an investigation playbook run along with the
+// playbook it belongs to, as task IDs are only unique within a playbook.
+type PlaybookRunTask struct {
+	InvestigationTask
+
+	PlaybookID   string
+	PlaybookName string
+
+	// IDs of the sub-playbook tasks leading to the playbook, empty for the
+	// root playbook
+	Path []string
+}
+
+// compareTaskIDs orders task IDs numerically, falling back to a string
+// comparison for non-numeric IDs.
+func compareTaskIDs(a, b string) int {
+	x, errX := strconv.Atoi(a)
+	y, errY := strconv.Atoi(b)
+	if errX != nil || errY != nil {
+		return strings.Compare(a, b)
+	}
+	return x - y
+}
+
+// ListTasks returns the tasks of the investigation playbook run, including
+// the tasks of sub-playbooks, sorted by playbook path then task ID.
+func (m *InvestigationModule) ListTasks(investigationID string, states ...TaskState) ([]PlaybookRunTask, error) {
+	playbook, err := m.GetPlaybook(investigationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []PlaybookRunTask
+	var walk func(p *InvestigationPlaybook, path []string)
+	walk = func(p *InvestigationPlaybook, path []string) {
+		for _, task := range p.Tasks {
+			if len(states) == 0 || slices.Contains(states, task.State) {
+				tasks = append(tasks, PlaybookRunTask{
+					InvestigationTask: task,
+					PlaybookID:        p.PlaybookID,
+					PlaybookName:      p.Name,
+					Path:              path,
+				})
+			}
+			if task.SubPlaybook != nil {
+				walk(task.SubPlaybook, append(slices.Clone(path), task.ID))
+			}
+		}
+	}
+	walk(&playbook, nil)
+
+	sort.Slice(tasks, func(i, j int) bool {
+		a := append(slices.Clone(tasks[i].Path), tasks[i].ID)
+		b := append(slices.Clone(tasks[j].Path), tasks[j].ID)
+		return slices.CompareFunc(a, b, compareTaskIDs) < 0
+	})
+
+	return tasks, nil
+}
+
+// CompleteTask completes a manual task, the answer is the branch label to
+// follow for conditional tasks.
+func (m *InvestigationModule) CompleteTask(c TaskCompletion) error {
+	fields := map[string]string{
+		"investigationId": c.InvestigationID,
+		"taskId":          c.TaskID,
+		"taskInput":       c.Answer,
+		"taskComment":     c.Comment,
+	}
+	if c.Version != 0 {
+		fields["version"] = strconv.Itoa(c.Version)
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "inv-playbook/task/complete",
+		WithForm(fields),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func (m *InvestigationModule) postTaskAction(endpoint string, action taskAction) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(action); err != nil {
+		return err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, endpoint,
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func (m *InvestigationModule) AssignTask(investigationID, taskID, assignee string) error {
+	return m.postTaskAction("inv-playbook/task/assign", taskAction{InvestigationID: investigationID, TaskID: taskID, Assignee: assignee})
+}
+
+func (m *InvestigationModule) AddTaskNote(investigationID, taskID, note string) error {
+	return m.postTaskAction("inv-playbook/task/note/add", taskAction{InvestigationID: investigationID, TaskID: taskID, Note: note})
+}
+
+func (m *InvestigationModule) RerunTask(investigationID, taskID string) error {
+	return m.postTaskAction("inv-playbook/task/rerun", taskAction{InvestigationID: investigationID, TaskID: taskID})
+}
@@ -0,0 +1,323 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type JobTrigger string
+
+const (
+	TimeJobTrigger JobTrigger = "time"
+	FeedJobTrigger JobTrigger = "feed"
+)
+
+type JobIntervalUnit string
+
+const (
+	MinutesJobInterval JobIntervalUnit = "minutes"
+	HoursJobInterval   JobIntervalUnit = "hours"
+	DaysJobInterval    JobIntervalUnit = "days"
+)
+
+type JobHumanCron struct {
+	TimePeriodType JobIntervalUnit `json:"timePeriodType"`
+	TimePeriod     int             `json:"timePeriod"`
+	Days           []string        `json:"days,omitempty"`
+}
+
+type JobSchedule struct {
+	Trigger   JobTrigger
+	Cron      string
+	Interval  time.Duration
+	StartDate time.Time
+	EndDate   time.Time
+	Feeds     []string
+	AllFeeds  bool
+}
+
+func CronSchedule(expr string) JobSchedule {
+	return JobSchedule{Trigger: TimeJobTrigger, Cron: expr}
+}
+
+func IntervalSchedule(interval time.Duration) JobSchedule {
+	return JobSchedule{Trigger: TimeJobTrigger, Interval: interval}
+}
+
+// FeedSchedule triggers the job when the given feeds finish fetching, or any
+// feed when none is given.
+func FeedSchedule(feeds ...string) JobSchedule {
+	return JobSchedule{Trigger: FeedJobTrigger, Feeds: feeds, AllFeeds: len(feeds) == 0}
+}
+
+func (s JobSchedule) Validate() error {
+	switch s.Trigger {
+	case TimeJobTrigger:
+		if s.Cron != "" && s.Interval != 0 {
+			return errors.New("job schedule cannot have both a cron expression and an interval")
+		}
+		if s.Cron != "" {
+			return ValidateCron(s.Cron)
+		}
+		if s.Interval < time.Minute || s.Interval%time.Minute != 0 {
+			return errors.Errorf("job interval %s must be a positive number of minutes", s.Interval)
+		}
+	case FeedJobTrigger:
+	default:
+		return errors.Errorf("unknown job trigger %q", s.Trigger)
+	}
+
+	if !s.EndDate.IsZero() && s.EndDate.Before(s.StartDate) {
+		return errors.New("job schedule ends before it starts")
+	}
+
+	return nil
+}
+
+func (s JobSchedule) humanCron() *JobHumanCron {
+	if s.Interval == 0 {
+		return nil
+	}
+
+	switch {
+	case s.Interval%(24*time.Hour) == 0:
+		return &JobHumanCron{TimePeriodType: DaysJobInterval, TimePeriod: int(s.Interval / (24 * time.Hour))}
+	case s.Interval%time.Hour == 0:
+		return &JobHumanCron{TimePeriodType: HoursJobInterval, TimePeriod: int(s.Interval / time.Hour)}
+	default:
+		return &JobHumanCron{TimePeriodType: MinutesJobInterval, TimePeriod: int(s.Interval / time.Minute)}
+	}
+}
+
+type Job struct {
+	ID                string        `json:"id"`
+	Version           int           `json:"version"`
+	CacheVersn        int           `json:"cacheVersn"`
+	Modified          time.Time     `json:"modified"`
+	Created           time.Time     `json:"created"`
+	SizeInBytes       int           `json:"sizeInBytes"`
+	Name              string        `json:"name"`
+	Type              string        `json:"type"`
+	PlaybookID        string        `json:"playbookId"`
+	Owner             string        `json:"owner"`
+	Tags              []string      `json:"tags"`
+	Details           string        `json:"details"`
+	Scheduled         bool          `json:"scheduled"`
+	Recurrent         bool          `json:"recurrent"`
+	Cron              string        `json:"cron"`
+	HumanCron         *JobHumanCron `json:"humanCron"`
+	StartDate         time.Time     `json:"startDate"`
+	EndingDate        time.Time     `json:"endingDate"`
+	EndingType        string        `json:"endingType"`
+	Times             int           `json:"times"`
+	IsFeed            bool          `json:"isFeed"`
+	IsAllFeeds        bool          `json:"isAllFeeds"`
+	SelectedFeeds     []string      `json:"selectedFeeds"`
+	ShouldTriggerNew  bool          `json:"shouldTriggerNew"`
+	CloseOnFinish     bool          `json:"closePrevRun"`
+	NotifyOwner       bool          `json:"notifyOwner"`
+	Paused            bool          `json:"paused"`
+	Running           bool          `json:"running"`
+	LastRunTime       time.Time     `json:"lastRunTime"`
+	NextRunTime       time.Time     `json:"nextRunTime"`
+	PreviousRunStatus string        `json:"previousRunStatus"`
+	SortValues        []string      `json:"sortValues"`
+}
+
+type JobUpsert struct {
+	ID               string
+	Version          int
+	Name             string
+	Type             string
+	PlaybookID       string
+	Tags             []string
+	Details          string
+	Schedule         JobSchedule
+	ShouldTriggerNew bool
+	CloseOnFinish    bool
+}
+
+type jobPayload struct {
+	ID               string        `json:"id,omitempty"`
+	Version          int           `json:"version,omitempty"`
+	Name             string        `json:"name"`
+	Type             string        `json:"type,omitempty"`
+	PlaybookID       string        `json:"playbookId,omitempty"`
+	Tags             []string      `json:"tags,omitempty"`
+	Details          string        `json:"details,omitempty"`
+	Scheduled        bool          `json:"scheduled"`
+	Recurrent        bool          `json:"recurrent"`
+	Cron             string        `json:"cron,omitempty"`
+	HumanCron        *JobHumanCron `json:"humanCron,omitempty"`
+	StartDate        *time.Time    `json:"startDate,omitempty"`
+	EndingDate       *time.Time    `json:"endingDate,omitempty"`
+	EndingType       string        `json:"endingType"`
+	IsFeed           bool          `json:"isFeed"`
+	IsAllFeeds       bool          `json:"isAllFeeds"`
+	SelectedFeeds    []string      `json:"selectedFeeds,omitempty"`
+	ShouldTriggerNew bool          `json:"shouldTriggerNew"`
+	CloseOnFinish    bool          `json:"closePrevRun"`
+}
+
+func (j JobUpsert) payload() jobPayload {
+	p := jobPayload{
+		ID:               j.ID,
+		Version:          j.Version,
+		Name:             j.Name,
+		Type:             j.Type,
+		PlaybookID:       j.PlaybookID,
+		Tags:             j.Tags,
+		Details:          j.Details,
+		EndingType:       "never",
+		ShouldTriggerNew: j.ShouldTriggerNew,
+		CloseOnFinish:    j.CloseOnFinish,
+	}
+
+	s := j.Schedule
+	if s.Trigger == FeedJobTrigger {
+		p.IsFeed, p.IsAllFeeds, p.SelectedFeeds = true, s.AllFeeds, s.Feeds
+		return p
+	}
+
+	p.Scheduled, p.Recurrent = true, true
+	p.Cron, p.HumanCron = s.Cron, s.humanCron()
+	if !s.StartDate.IsZero() {
+		p.StartDate = &s.StartDate
+	}
+	if !s.EndDate.IsZero() {
+		p.EndingDate, p.EndingType = &s.EndDate, "by_date"
+	}
+
+	return p
+}
+
+// searchPageSize is the number of items requested per page of a search.
+const searchPageSize = 500
+
+type jobSearchQuery struct {
+	Page  int    `json:"page"`
+	Size  int    `json:"size"`
+	Query string `json:"query"`
+}
+
+type JobSearch struct {
+	Data  []Job `json:"data"`
+	Total int   `json:"total"`
+}
+
+type JobModule struct {
+	client *Client
+}
+
+// ListJobs returns every job, requesting the search page by page.
+func (m *JobModule) ListJobs() ([]Job, error) {
+	var jobs []Job
+	for page := 0; ; page++ {
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(jobSearchQuery{Page: page, Size: searchPageSize}); err != nil {
+			return nil, err
+		}
+
+		req, err := m.client.NewRequest(
+			http.MethodPost, "jobs/search",
+			WithBody(buf),
+			WithHeader("Content-Type", "application/json"),
+			WithHeader("Accept", "application/json"),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := m.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		search, err := Decode[JobSearch](resp)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, search.Data...)
+		if len(search.Data) == 0 || len(jobs) >= search.Total {
+			return jobs, nil
+		}
+	}
+}
+
+func (m *JobModule) UpsertJob(j JobUpsert) (Job, error) {
+	if err := j.Schedule.Validate(); err != nil {
+		return Job{}, err
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(j.payload()); err != nil {
+		return Job{}, err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "jobs",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Job{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Job{}, err
+	}
+
+	return Decode[Job](resp)
+}
+
+func (m *JobModule) postIDs(endpoint string, ids ...string) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string][]string{"ids": ids}); err != nil {
+		return err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, endpoint,
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func (m *JobModule) PauseJobs(ids ...string) error {
+	return m.postIDs("jobs/pause", ids...)
+}
+
+func (m *JobModule) ResumeJobs(ids ...string) error {
+	return m.postIDs("jobs/resume", ids...)
+}
+
+func (m *JobModule) RunJobs(ids ...string) error {
+	return m.postIDs("jobs/run", ids...)
+}
+
+func (m *JobModule) DeleteJob(id string) error {
+	req, err := m.client.NewRequest(
+		http.MethodDelete, "jobs/"+id,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
@@ -0,0 +1,294 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type LayoutGroup string
+
+const (
+	IncidentLayoutGroup  LayoutGroup = "incident"
+	IndicatorLayoutGroup LayoutGroup = "indicator"
+)
+
+type LayoutItem struct {
+	ID          string `json:"id"`
+	FieldID     string `json:"fieldId"`
+	Name        string `json:"name"`
+	Index       int    `json:"index"`
+	StartCol    int    `json:"startCol"`
+	EndCol      int    `json:"endCol"`
+	Height      int    `json:"height"`
+	DropEffect  string `json:"dropEffect"`
+	ListID      string `json:"listId"`
+	SectionItem string `json:"sectionItemType"`
+	Args        any    `json:"args"`
+}
+
+type LayoutSection struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Type        string       `json:"type"`
+	Description string       `json:"description"`
+	IsVisible   bool         `json:"isVisible"`
+	ReadOnly    bool         `json:"readOnly"`
+	DisplayType string       `json:"displayType"`
+	HideName    bool         `json:"hideName"`
+	H           int          `json:"h"`
+	W           int          `json:"w"`
+	X           int          `json:"x"`
+	Y           int          `json:"y"`
+	I           string       `json:"i"`
+	MinH        int          `json:"minH"`
+	MinW        int          `json:"minW"`
+	MaxW        int          `json:"maxW"`
+	Moved       bool         `json:"moved"`
+	Static      bool         `json:"static"`
+	Query       any          `json:"query"`
+	QueryType   string       `json:"queryType"`
+	Items       []LayoutItem `json:"items"`
+	Fields      []LayoutItem `json:"fields"`
+}
+
+type LayoutTab struct {
+	ID              string          `json:"id"`
+	Name            string          `json:"name"`
+	Type            string          `json:"type"`
+	Hidden          bool            `json:"hidden"`
+	ShowEmptyFields bool            `json:"showEmptyFields"`
+	Sections        []LayoutSection `json:"sections"`
+}
+
+type LayoutTabs struct {
+	Tabs []LayoutTab `json:"tabs"`
+}
+
+type Layout struct {
+	ID                  string      `json:"id"`
+	Version             int         `json:"version"`
+	CacheVersn          int         `json:"cacheVersn"`
+	Modified            time.Time   `json:"modified"`
+	Created             time.Time   `json:"created"`
+	SizeInBytes         int         `json:"sizeInBytes"`
+	PackID              string      `json:"packID"`
+	PackName            string      `json:"packName"`
+	ItemVersion         string      `json:"itemVersion"`
+	FromServerVersion   string      `json:"fromServerVersion"`
+	ToServerVersion     string      `json:"toServerVersion"`
+	PropagationLabels   []string    `json:"propagationLabels"`
+	DefinitionId        string      `json:"definitionId"`
+	Name                string      `json:"name"`
+	PrevName            string      `json:"prevName"`
+	Description         string      `json:"description"`
+	Group               LayoutGroup `json:"group"`
+	System              bool        `json:"system"`
+	Locked              bool        `json:"locked"`
+	Detached            bool        `json:"detached"`
+	DetailsV2           *LayoutTabs `json:"detailsV2,omitempty"`
+	Details             *LayoutTabs `json:"details,omitempty"`
+	Edit                *LayoutTabs `json:"edit,omitempty"`
+	Close               *LayoutTabs `json:"close,omitempty"`
+	QuickView           *LayoutTabs `json:"quickView,omitempty"`
+	Mobile              *LayoutTabs `json:"mobile,omitempty"`
+	IndicatorsDetails   *LayoutTabs `json:"indicatorsDetails,omitempty"`
+	IndicatorsQuickView *LayoutTabs `json:"indicatorsQuickView,omitempty"`
+	SortValues          []string    `json:"sortValues"`
+}
+
+// FieldIDs returns the sorted, deduplicated IDs of the fields displayed in
+// any of the layout views.
+func (l Layout) FieldIDs() []string {
+	seen := make(map[string]bool)
+	for _, view := range []*LayoutTabs{
+		l.DetailsV2, l.Details, l.Edit, l.Close, l.QuickView, l.Mobile, l.IndicatorsDetails, l.IndicatorsQuickView,
+	} {
+		if view == nil {
+			continue
+		}
+		for _, tab := range view.Tabs {
+			for _, section := range tab.Sections {
+				for _, items := range [][]LayoutItem{section.Items, section.Fields} {
+					for _, item := range items {
+						if item.FieldID != "" {
+							seen[item.FieldID] = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+type LayoutModule struct {
+	client *Client
+}
+
+func (m *LayoutModule) ListLayouts() ([]Layout, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "layouts",
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode[[]Layout](resp)
+}
+
+func (m *LayoutModule) GetLayout(id string) (Layout, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "layout/"+id,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Layout{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Layout{}, err
+	}
+
+	return Decode[Layout](resp)
+}
+
+func (m *LayoutModule) UpsertLayout(l Layout) (Layout, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(l); err != nil {
+		return Layout{}, err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "layout/save",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Layout{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Layout{}, err
+	}
+
+	return Decode[Layout](resp)
+}
+
+func (m *LayoutModule) DeleteLayout(id string) error {
+	req, err := m.client.NewRequest(
+		http.MethodDelete, "layout/"+id,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func (m *LayoutModule) ImportLayout(filename string, content io.Reader) (Layout, error) {
+	req, err := m.client.NewRequest(
+		http.MethodPost, "layouts/import",
+		WithFile("file", filename, content),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Layout{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Layout{}, err
+	}
+
+	return Decode[Layout](resp)
+}
+
+// ExportLayout returns the layout in the content JSON format, the caller is
+// responsible for closing it.
+func (m *LayoutModule) ExportLayout(id string) (io.ReadCloser, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "layout/"+id+"/download",
+		WithHeader("Accept", "application/octet-stream"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func ParseLayout(r io.Reader) (Layout, error) {
+	var l Layout
+	return l, json.NewDecoder(r).Decode(&l)
+}
+
+// ValidateLayoutFields returns the fields referenced by the layout which do
+// not exist on the server. Only incident layouts are checked.
+func (m *LayoutModule) ValidateLayoutFields(l Layout) ([]string, error) {
+	if l.Group != "" && l.Group != IncidentLayoutGroup {
+		return nil, nil
+	}
+
+	fields, err := m.client.IncidentField.ListFields()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(fields)*2)
+	for _, f := range fields {
+		known[strings.ToLower(f.CliName)] = true
+		known[strings.TrimPrefix(strings.ToLower(f.ID), "incident_")] = true
+	}
+
+	var missing []string
+	for _, id := range l.FieldIDs() {
+		if !known[strings.TrimPrefix(strings.ToLower(id), "incident_")] {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing, nil
+}
+
+func (m *LayoutModule) CheckLayoutFields(l Layout) error {
+	missing, err := m.ValidateLayoutFields(l)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return errors.Errorf("layout %q references unknown fields: %s", l.Name, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
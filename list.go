@@ -0,0 +1,108 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type List struct {
+	ID                string    `json:"id"`
+	Version           int       `json:"version"`
+	CacheVersn        int       `json:"cacheVersn"`
+	Modified          time.Time `json:"modified"`
+	Created           time.Time `json:"created"`
+	SizeInBytes       int       `json:"sizeInBytes"`
+	PackID            string    `json:"packID"`
+	PackName          string    `json:"packName"`
+	ItemVersion       string    `json:"itemVersion"`
+	FromServerVersion string    `json:"fromServerVersion"`
+	ToServerVersion   string    `json:"toServerVersion"`
+	PropagationLabels []string  `json:"propagationLabels"`
+	DefinitionId      string    `json:"definitionId"`
+	Name              string    `json:"name"`
+	Type              string    `json:"type"`
+	Description       string    `json:"description"`
+	Data              string    `json:"data"`
+	Tags              []string  `json:"tags"`
+	Truncated         bool      `json:"truncated"`
+	Locked            bool      `json:"locked"`
+	System            bool      `json:"system"`
+}
+
+type ListUpsert struct {
+	ID                string   `json:"id,omitempty"`
+	Version           int      `json:"version,omitempty"`
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	Description       string   `json:"description,omitempty"`
+	Data              string   `json:"data"`
+	Tags              []string `json:"tags,omitempty"`
+	PropagationLabels []string `json:"propagationLabels,omitempty"`
+}
+
+type ListModule struct {
+	client *Client
+}
+
+func (m *ListModule) GetLists() ([]List, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "lists",
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode[[]List](resp)
+}
+
+func (m *ListModule) SaveList(l ListUpsert) (List, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(l); err != nil {
+		return List{}, err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "lists/save",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return List{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return List{}, err
+	}
+
+	return Decode[List](resp)
+}
+
+func (m *ListModule) DeleteList(id string) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string]string{"id": id}); err != nil {
+		return err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "lists/delete",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
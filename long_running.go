@@ -0,0 +1,140 @@
+package xsoar
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const longRunningPortParam = "longRunningPort"
+
+type LongRunningInstance struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Brand         string `json:"brand"`
+	Engine        string `json:"engine"`
+	EngineGroup   string `json:"engineGroup"`
+	LongRunningId string `json:"longRunningId"`
+	Enabled       bool   `json:"enabled"`
+	Port          int    `json:"port"`
+}
+
+func parsePort(value any) (int, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return v, nil
+	case float64:
+		return int(v), nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(v)
+	default:
+		return 0, errors.Errorf("invalid port value: %v", value)
+	}
+}
+
+func (m *IntegrationModule) ListLongRunningInstances() ([]LongRunningInstance, error) {
+	instances, err := m.GetInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []LongRunningInstance
+	for _, instance := range instances {
+		if !instance.IsLongRunning {
+			continue
+		}
+
+		lr := LongRunningInstance{
+			ID:            instance.ID,
+			Name:          instance.Name,
+			Brand:         instance.Brand,
+			Engine:        instance.Engine,
+			EngineGroup:   instance.EngineGroup,
+			LongRunningId: instance.LongRunningId,
+			Enabled:       instance.Enabled,
+		}
+
+		if param, ok := instance.Param(longRunningPortParam); ok {
+			value, err := param.StringValue()
+			if err != nil {
+				return nil, err
+			}
+			if lr.Port, err = parsePort(value); err != nil {
+				return nil, errors.Wrapf(err, "instance %q", instance.Name)
+			}
+		}
+
+		result = append(result, lr)
+	}
+
+	return result, nil
+}
+
+// CheckPortCollision returns an error if the listener port of the instance is
+// already allocated to another enabled long-running instance on the same
+// engine or engine group.
+func (m *IntegrationModule) CheckPortCollision(instance IntegrationInstanceUpsert) error {
+	var port int
+	for _, d := range instance.Data {
+		if d.Name != longRunningPortParam {
+			continue
+		}
+		var err error
+		if port, err = parsePort(d.Value); err != nil {
+			return err
+		}
+	}
+
+	if port == 0 {
+		return nil
+	}
+
+	existing, err := m.ListLongRunningInstances()
+	if err != nil {
+		return err
+	}
+
+	for _, lr := range existing {
+		if !lr.Enabled || lr.Port != port || lr.ID == instance.ID || lr.Name == instance.PrevName {
+			continue
+		}
+		if lr.Engine == instance.Engine && lr.EngineGroup == instance.EngineGroup {
+			return errors.Errorf("port %d already used by instance %q", port, lr.Name)
+		}
+	}
+
+	return nil
+}
+
+// RestartLongRunning restarts the container of an enabled long-running
+// instance by disabling then re-enabling it.
+func (m *IntegrationModule) RestartLongRunning(id string) (IntegrationInstance, error) {
+	instance, err := m.GetInstance(id)
+	if err != nil {
+		return IntegrationInstance{}, err
+	}
+
+	if !instance.IsLongRunning {
+		return IntegrationInstance{}, errors.Errorf("instance %q is not long-running", instance.Name)
+	}
+
+	if !instance.Enabled {
+		return IntegrationInstance{}, errors.Errorf("instance %q is disabled", instance.Name)
+	}
+
+	if _, err := m.updateInstance(id, WithInstanceEnabled(false)); err != nil {
+		return IntegrationInstance{}, errors.Wrapf(err, "disabling instance %q", instance.Name)
+	}
+
+	restarted, err := m.updateInstance(id, WithInstanceEnabled(true))
+	if err != nil {
+		return IntegrationInstance{}, errors.Wrapf(err, "re-enabling instance %q, it is left disabled", instance.Name)
+	}
+
+	return restarted, nil
+}
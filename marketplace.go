@@ -0,0 +1,265 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+type PackDependency struct {
+	Name          string `json:"name"`
+	Mandatory     bool   `json:"mandatory"`
+	MinVersion    string `json:"minVersion"`
+	Author        string `json:"author"`
+	Certification string `json:"certification"`
+}
+
+type Pack struct {
+	ID              string                    `json:"id"`
+	Name            string                    `json:"name"`
+	Description     string                    `json:"description"`
+	Author          string                    `json:"author"`
+	Support         string                    `json:"support"`
+	Certification   string                    `json:"certification"`
+	Categories      []string                  `json:"categories"`
+	Tags            []string                  `json:"tags"`
+	UseCases        []string                  `json:"useCases"`
+	CurrentVersion  string                    `json:"currentVersion"`
+	LatestVersion   string                    `json:"latestVersion"`
+	UpdateAvailable bool                      `json:"updateAvailable"`
+	Installed       bool                      `json:"installed"`
+	InstalledBy     string                    `json:"installedBy"`
+	Deprecated      bool                      `json:"deprecated"`
+	Premium         bool                      `json:"premium"`
+	Price           int                       `json:"price"`
+	Dependencies    map[string]PackDependency `json:"dependencies"`
+	Created         time.Time                 `json:"created"`
+	Updated         time.Time                 `json:"updated"`
+}
+
+type PackSearch struct {
+	Packs []Pack `json:"packs"`
+	Total int    `json:"total"`
+}
+
+type PackVersion struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+type PackInstallOptions struct {
+	InstallDependencies bool
+	IgnoreWarnings      bool
+}
+
+type packInstall struct {
+	Packs          []PackVersion `json:"packs"`
+	IgnoreWarnings bool          `json:"ignoreWarnings"`
+}
+
+type packSearchQuery struct {
+	Page        int    `json:"page"`
+	Size        int    `json:"size"`
+	PackQuery   string `json:"packsQuery"`
+	OnlyUpdates bool   `json:"onlyUpdates,omitempty"`
+}
+
+type MarketplaceModule struct {
+	client *Client
+}
+
+func (m *MarketplaceModule) ListInstalledPacks() ([]Pack, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "contentpacks/metadata/installed",
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode[[]Pack](resp)
+}
+
+// SearchPacks returns every marketplace pack matching the query, requesting
+// the search page by page.
+func (m *MarketplaceModule) SearchPacks(query string) ([]Pack, error) {
+	var packs []Pack
+	for page := 0; ; page++ {
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(packSearchQuery{Page: page, Size: searchPageSize, PackQuery: query}); err != nil {
+			return nil, err
+		}
+
+		req, err := m.client.NewRequest(
+			http.MethodPost, "contentpacks/marketplace/search",
+			WithBody(buf),
+			WithHeader("Content-Type", "application/json"),
+			WithHeader("Accept", "application/json"),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := m.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		search, err := Decode[PackSearch](resp)
+		if err != nil {
+			return nil, err
+		}
+
+		packs = append(packs, search.Packs...)
+		if len(search.Packs) == 0 || len(packs) >= search.Total {
+			return packs, nil
+		}
+	}
+}
+
+func (m *MarketplaceModule) GetPack(id string) (Pack, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "contentpacks/marketplace/"+id,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Pack{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Pack{}, err
+	}
+
+	return Decode[Pack](resp)
+}
+
+// ResolveDependencies returns the mandatory dependencies of the packs, direct
+// and transitive, which are not installed yet. Dependencies are listed before
+// the packs depending on them.
+func (m *MarketplaceModule) ResolveDependencies(packs ...PackVersion) ([]PackVersion, error) {
+	installed, err := m.ListInstalledPacks()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(installed)+len(packs))
+	for _, p := range installed {
+		seen[p.ID] = true
+	}
+	for _, p := range packs {
+		seen[p.ID] = true
+	}
+
+	var resolved []PackVersion
+	var visit func(pack Pack) error
+	visit = func(pack Pack) error {
+		for depID, dep := range pack.Dependencies {
+			if !dep.Mandatory || seen[depID] {
+				continue
+			}
+			seen[depID] = true
+
+			depPack, err := m.GetPack(depID)
+			if err != nil {
+				return err
+			}
+
+			if err := visit(depPack); err != nil {
+				return err
+			}
+
+			resolved = append(resolved, PackVersion{ID: depID, Version: depPack.LatestVersion})
+		}
+
+		return nil
+	}
+
+	for _, p := range packs {
+		pack, err := m.GetPack(p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := visit(pack); err != nil {
+			return nil, err
+		}
+	}
+
+	return resolved, nil
+}
+
+// InstallPacks installs or upgrades the packs to the given versions. An empty
+// version installs the latest one.
+func (m *MarketplaceModule) InstallPacks(opt PackInstallOptions, packs ...PackVersion) error {
+	if opt.InstallDependencies {
+		deps, err := m.ResolveDependencies(packs...)
+		if err != nil {
+			return err
+		}
+		packs = append(deps, packs...)
+	}
+
+	for i, p := range packs {
+		if p.Version != "" {
+			continue
+		}
+		pack, err := m.GetPack(p.ID)
+		if err != nil {
+			return err
+		}
+		packs[i].Version = pack.LatestVersion
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(packInstall{Packs: packs, IgnoreWarnings: opt.IgnoreWarnings}); err != nil {
+		return err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "contentpacks/marketplace/install",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func (m *MarketplaceModule) UninstallPack(id string) error {
+	req, err := m.client.NewRequest(
+		http.MethodDelete, "contentpacks/installed/"+id,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+func (m *MarketplaceModule) UploadPack(filename string, content io.Reader) error {
+	req, err := m.client.NewRequest(
+		http.MethodPost, "contentpacks/installed/upload",
+		WithFile("file", filename, content),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
@@ -0,0 +1,91 @@
+package migrate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Journal records the migrated items so that an interrupted migration can be
+// resumed without writing them again.
+type Journal struct {
+	path string
+
+	mu   sync.Mutex
+	Done map[string]time.Time `json:"done"`
+}
+
+// OpenJournal loads the journal at path, or starts an empty one when the file
+// does not exist yet.
+func OpenJournal(path string) (*Journal, error) {
+	j := &Journal{path: path, Done: make(map[string]time.Time)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, errors.Wrapf(err, "reading journal %s", path)
+	}
+
+	if j.Done == nil {
+		j.Done = make(map[string]time.Time)
+	}
+
+	return j, nil
+}
+
+func (j *Journal) IsDone(key string) bool {
+	if j == nil {
+		return false
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, ok := j.Done[key]
+	return ok
+}
+
+// MarkDone records the item and persists the journal.
+func (j *Journal) MarkDone(key string) error {
+	if j == nil {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.Done[key] = time.Now().UTC()
+
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), j.path)
+}
@@ -0,0 +1,388 @@
+package migrate
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+	"github.com/pkg/errors"
+)
+
+// SecretPrompter asks for a secret which cannot be exported from the source,
+// such as a credential password or an encrypted instance param.
+type SecretPrompter func(kind, name, field string) (string, error)
+
+// LinePrompter reads each secret as a line of in after writing a prompt to
+// out. Input is echoed, use a dedicated prompter for interactive terminals.
+func LinePrompter(in io.Reader, out io.Writer) SecretPrompter {
+	reader := bufio.NewReader(in)
+	return func(kind, name, field string) (string, error) {
+		if _, err := fmt.Fprintf(out, "%s %q %s: ", kind, name, field); err != nil {
+			return "", err
+		}
+
+		line, err := reader.ReadString('\n')
+		if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+			return "", err
+		}
+
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+}
+
+type Step struct {
+	Kind    string
+	Name    string
+	Skipped bool
+}
+
+func (s Step) key() string {
+	return s.Kind + "/" + s.Name
+}
+
+type Options struct {
+	// Translation applied to every item, Identity when nil
+	Translator Translator
+
+	// Asks for the secrets. When nil the secrets already stored on the
+	// target are kept, and items with secrets missing from the target fail.
+	Prompt SecretPrompter
+
+	// Resumes the migration, items recorded as done are not written again
+	Journal *Journal
+
+	// Only report the steps through Progress
+	DryRun bool
+
+	// Called for every item
+	Progress func(Step)
+}
+
+type Migrator struct {
+	source, target *xsoar.Client
+	opt            Options
+}
+
+func New(source, target *xsoar.Client, opt Options) *Migrator {
+	if opt.Translator == nil {
+		opt.Translator = Identity{}
+	}
+	return &Migrator{source: source, target: target, opt: opt}
+}
+
+func (m *Migrator) prompt(kind, name, field string) (string, error) {
+	if m.opt.Prompt == nil {
+		return "", errors.Errorf("%s %q %s is not stored on the target and no prompt is set", kind, name, field)
+	}
+	return m.opt.Prompt(kind, name, field)
+}
+
+// step runs fn for the item unless it is skipped, already journaled or the
+// migration is a dry run.
+func (m *Migrator) step(kind, name string, keep bool, fn func() error) error {
+	s := Step{Kind: kind, Name: name, Skipped: !keep || m.opt.Journal.IsDone(kind+"/"+name)}
+	if m.opt.Progress != nil {
+		m.opt.Progress(s)
+	}
+
+	if s.Skipped || m.opt.DryRun {
+		return nil
+	}
+
+	if err := fn(); err != nil {
+		return errors.Wrapf(err, "migrating %s %q", kind, name)
+	}
+
+	return m.opt.Journal.MarkDone(s.key())
+}
+
+// Run migrates roles, credentials, lists, integration instances and
+// sysconfig, in that order so that instances can use the credentials.
+func (m *Migrator) Run() error {
+	for _, fn := range []func() error{m.roles, m.credentials, m.lists, m.instances, m.sysconfig} {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) roles() error {
+	roles, err := m.source.Role.GetRoles()
+	if err != nil {
+		return err
+	}
+
+	existing, err := m.target.Role.GetRoles()
+	if err != nil {
+		return err
+	}
+
+	targets := make(map[string]xsoar.Role, len(existing))
+	for _, r := range existing {
+		targets[r.Name] = r
+	}
+
+	for _, role := range roles {
+		name := role.Name
+		keep := m.opt.Translator.Role(&role)
+		err := m.step("role", name, keep, func() error {
+			role.ID, role.Version = "", 0
+			if target, ok := targets[role.Name]; ok {
+				role.ID, role.Version = target.ID, target.Version
+			}
+			_, err := m.target.Role.UpsertRole(role)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) credentials() error {
+	search, err := m.source.Integration.ListCredentials()
+	if err != nil {
+		return err
+	}
+
+	existing, err := m.target.Integration.ListCredentials()
+	if err != nil {
+		return err
+	}
+
+	targets := make(map[string]xsoar.Credential, len(existing.Credentials))
+	for _, c := range existing.Credentials {
+		targets[c.Name] = c
+	}
+
+	for _, c := range search.Credentials {
+		credential := xsoar.CredentialUpsert{Name: c.Name, User: c.User, Workgroup: c.Workgroup}
+		keep := !c.Locked && m.opt.Translator.Credential(&credential)
+
+		err := m.step("credential", c.Name, keep, func() error {
+			target, exists := targets[credential.Name]
+			if exists {
+				credential.ID, credential.Version = target.ID, target.Version
+			}
+
+			var err error
+			switch {
+			case !c.HasPassword:
+			case m.opt.Prompt == nil && exists && target.HasPassword:
+				credential.HasPassword = true
+			default:
+				if credential.Password, err = m.prompt("credential", c.Name, "password"); err != nil {
+					return err
+				}
+			}
+
+			switch {
+			case !c.HasCertificate:
+			case m.opt.Prompt == nil && exists && target.HasCertificate:
+				credential.HasCertificate, credential.HasCertificatePass = true, target.HasCertificatePass
+			default:
+				if credential.SSHKey, err = m.prompt("credential", c.Name, "certificate"); err != nil {
+					return err
+				}
+			}
+
+			_, err = m.target.Integration.UpsertCredential(credential)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) lists() error {
+	lists, err := m.source.List.GetLists()
+	if err != nil {
+		return err
+	}
+
+	existing, err := m.target.List.GetLists()
+	if err != nil {
+		return err
+	}
+
+	targets := make(map[string]xsoar.List, len(existing))
+	for _, l := range existing {
+		targets[l.Name] = l
+	}
+
+	for _, l := range lists {
+		list := xsoar.ListUpsert{
+			Name:              l.Name,
+			Type:              l.Type,
+			Description:       l.Description,
+			Data:              l.Data,
+			Tags:              l.Tags,
+			PropagationLabels: l.PropagationLabels,
+		}
+		keep := !l.System && m.opt.Translator.List(&list)
+
+		err := m.step("list", l.Name, keep, func() error {
+			if l.Truncated {
+				return errors.New("list data was truncated by the source server")
+			}
+			if target, ok := targets[list.Name]; ok {
+				list.ID, list.Version = target.ID, target.Version
+			}
+			_, err := m.target.List.SaveList(list)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// instanceSecrets prompts for the encrypted params of the instance, stored
+// credentials references are kept as they were migrated by name. Without a
+// prompt the secrets stored on the existing target instance are kept.
+func (m *Migrator) instanceSecrets(instance *xsoar.IntegrationInstanceUpsert, target *xsoar.IntegrationInstance) error {
+	var stored xsoar.IntegrationInstanceUpsert
+	if target != nil && m.opt.Prompt == nil {
+		var err error
+		if stored, err = target.ToUpsert(); err != nil {
+			return err
+		}
+		stored.KeepSecrets()
+	}
+
+	storedValue := func(name string) (any, bool) {
+		for _, d := range stored.Data {
+			if d.Name == name && d.Hasvalue {
+				return d.Value, true
+			}
+		}
+		return nil, false
+	}
+
+	for i, d := range instance.Data {
+		if !d.Hasvalue {
+			continue
+		}
+
+		switch d.Type {
+		case xsoar.EncryptedParamType, xsoar.EncryptedLongTextParamType:
+			if value, ok := storedValue(d.Name); ok {
+				instance.Data[i].Value = value
+				continue
+			}
+
+			secret, err := m.prompt("instance", instance.Name, d.Name)
+			if err != nil {
+				return err
+			}
+			instance.Data[i].Value = secret
+
+		case xsoar.AuthenticationParamType:
+			creds, _ := d.Value.(xsoar.InstanceCredentials)
+			if creds.Credential != "" {
+				continue
+			}
+
+			if value, ok := storedValue(d.Name); ok {
+				if storedCreds, _ := value.(xsoar.InstanceCredentials); storedCreds.Password != "" {
+					creds.Password, creds.PasswordChanged = storedCreds.Password, false
+					instance.Data[i].Value = creds
+					continue
+				}
+			}
+
+			secret, err := m.prompt("instance", instance.Name, fmt.Sprintf("%s (%s)", d.Name, creds.Identifier))
+			if err != nil {
+				return err
+			}
+			creds.Password, creds.PasswordChanged = secret, true
+			instance.Data[i].Value = creds
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) instances() error {
+	instances, err := m.source.Integration.GetInstances()
+	if err != nil {
+		return err
+	}
+
+	existing, err := m.target.Integration.GetInstances()
+	if err != nil {
+		return err
+	}
+
+	targets := make(map[string]xsoar.IntegrationInstance, len(existing))
+	for _, i := range existing {
+		targets[i.Name] = i
+	}
+
+	for _, source := range instances {
+		if source.IsBuiltin || source.IsSystemIntegration {
+			continue
+		}
+
+		instance, err := source.ToUpsert()
+		if err != nil {
+			return errors.Wrapf(err, "reading instance %q", source.Name)
+		}
+		instance.ID, instance.Version, instance.PrevName = "", 0, ""
+		keep := m.opt.Translator.Instance(&instance)
+
+		err = m.step("instance", source.Name, keep, func() error {
+			var existing *xsoar.IntegrationInstance
+			if target, ok := targets[instance.Name]; ok {
+				instance.ID, instance.Version, instance.PrevName = target.ID, target.Version, target.Name
+				existing = &target
+			}
+
+			if err := m.instanceSecrets(&instance, existing); err != nil {
+				return err
+			}
+
+			_, err := m.target.Integration.UpsertInstance(instance)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) sysconfig() error {
+	source, err := m.source.Server.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	data := make(map[string]string)
+	for key, value := range source.SysConfig {
+		if k, v, ok := m.opt.Translator.SysConfig(key, value); ok {
+			data[k] = v
+		}
+	}
+
+	return m.step("sysconfig", "all", len(data) > 0, func() error {
+		target, err := m.target.Server.GetConfig()
+		if err != nil {
+			return err
+		}
+
+		_, err = m.target.Server.UpdateConfig(xsoar.SystemConfigUpdate{Data: data, Version: target.Version})
+		return err
+	})
+}
@@ -0,0 +1,87 @@
+package migrate
+
+import (
+	"strings"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+// Translator adapts the items read from the source server to the target
+// server version. Returning false skips the item.
+type Translator interface {
+	Role(r *xsoar.Role) bool
+	Credential(c *xsoar.CredentialUpsert) bool
+	List(l *xsoar.ListUpsert) bool
+	Instance(i *xsoar.IntegrationInstanceUpsert) bool
+	SysConfig(key, value string) (string, string, bool)
+}
+
+// Identity migrates every item unchanged, for servers of the same version.
+type Identity struct{}
+
+func (Identity) Role(*xsoar.Role) bool                          { return true }
+func (Identity) Credential(*xsoar.CredentialUpsert) bool        { return true }
+func (Identity) List(*xsoar.ListUpsert) bool                    { return true }
+func (Identity) Instance(*xsoar.IntegrationInstanceUpsert) bool { return true }
+
+func (Identity) SysConfig(key, value string) (string, string, bool) {
+	return key, value, true
+}
+
+// V6ToV8 translates an XSOAR 6 on-prem configuration for XSOAR 8 SaaS, where
+// the server, proxy and directory settings are managed by the tenant and
+// instances run on engines registered again on the target. It drops the
+// unsupported settings and remaps engines but renames no field, wrap it in
+// a custom Translator to rename fields.
+type V6ToV8 struct {
+	// Engine names or IDs of the source mapped to the ones of the target,
+	// instances on unmapped engines are skipped. Map an engine to "" to move
+	// its instances to the main server.
+	Engines map[string]string
+}
+
+var v8ManagedSysConfig = []string{
+	"server.", "http_proxy", "https_proxy", "no_proxy", "ldap.", "saml.", "db.", "ha.", "elasticsearch.", "bind.",
+}
+
+func (V6ToV8) Role(r *xsoar.Role) bool {
+	// groups are mapped in the Cortex Gateway on XSOAR 8
+	r.ADGroups, r.SamlGroups = nil, nil
+	r.AllRoles, r.AllPermissions = nil, nil
+	return !r.Locked
+}
+
+func (V6ToV8) Credential(c *xsoar.CredentialUpsert) bool {
+	c.Workgroup = ""
+	return true
+}
+
+func (V6ToV8) List(l *xsoar.ListUpsert) bool {
+	return true
+}
+
+func (t V6ToV8) Instance(i *xsoar.IntegrationInstanceUpsert) bool {
+	for _, engine := range []*string{&i.Engine, &i.EngineGroup} {
+		if *engine == "" {
+			continue
+		}
+
+		target, ok := t.Engines[*engine]
+		if !ok {
+			return false
+		}
+		*engine = target
+	}
+
+	i.PropagationLabels = nil
+	return true
+}
+
+func (V6ToV8) SysConfig(key, value string) (string, string, bool) {
+	for _, prefix := range v8ManagedSysConfig {
+		if strings.HasPrefix(key, prefix) {
+			return "", "", false
+		}
+	}
+	return key, value, true
+}
@@ -0,0 +1,294 @@
+package xsoar
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+type PackLock struct {
+	ID      string `json:"id" yaml:"id"`
+	Version string `json:"version" yaml:"version"`
+}
+
+type PackLockfile struct {
+	// Remove installed packs missing from the lockfile
+	Prune bool       `json:"prune" yaml:"prune"`
+	Packs []PackLock `json:"packs" yaml:"packs"`
+}
+
+// ParsePackLockfile reads a lockfile in YAML or JSON.
+func ParsePackLockfile(r io.Reader) (PackLockfile, error) {
+	var lock PackLockfile
+	if err := yaml.NewDecoder(r).Decode(&lock); err != nil {
+		return PackLockfile{}, err
+	}
+
+	seen := make(map[string]bool, len(lock.Packs))
+	for _, p := range lock.Packs {
+		if p.ID == "" || p.Version == "" {
+			return PackLockfile{}, errors.Errorf("pack %q must have an id and a version", p.ID)
+		}
+		if !validVersion(p.Version) {
+			return PackLockfile{}, errors.Errorf("pack %q has invalid version %q", p.ID, p.Version)
+		}
+		if seen[p.ID] {
+			return PackLockfile{}, errors.Errorf("pack %q is locked more than once", p.ID)
+		}
+		seen[p.ID] = true
+	}
+
+	return lock, nil
+}
+
+func (l PackLockfile) WriteYAML(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(l); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+type PackActionType string
+
+const (
+	InstallPackAction   PackActionType = "install"
+	UpgradePackAction   PackActionType = "upgrade"
+	DowngradePackAction PackActionType = "downgrade"
+	RemovePackAction    PackActionType = "remove"
+)
+
+type PackAction struct {
+	Type PackActionType `json:"type"`
+	ID   string         `json:"id"`
+	From string         `json:"from,omitempty"`
+	To   string         `json:"to,omitempty"`
+}
+
+type PackPlan struct {
+	Actions []PackAction `json:"actions"`
+}
+
+func (p PackPlan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// validVersion reports whether v is a dotted numeric version such as 1.10.2.
+func validVersion(v string) bool {
+	for _, part := range strings.Split(v, ".") {
+		if n, err := strconv.Atoi(part); err != nil || n < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// compareVersions compares dotted numeric versions such as 1.10.2, segments
+// which are not numbers are compared as strings.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		sx, sy := "0", "0"
+		if i < len(as) {
+			sx = as[i]
+		}
+		if i < len(bs) {
+			sy = bs[i]
+		}
+
+		x, errX := strconv.Atoi(sx)
+		y, errY := strconv.Atoi(sy)
+		if errX != nil || errY != nil {
+			if c := strings.Compare(sx, sy); c != 0 {
+				return c
+			}
+			continue
+		}
+
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func (m *MarketplaceModule) LockInstalledPacks() (PackLockfile, error) {
+	installed, err := m.ListInstalledPacks()
+	if err != nil {
+		return PackLockfile{}, err
+	}
+
+	lock := PackLockfile{}
+	for _, p := range installed {
+		lock.Packs = append(lock.Packs, PackLock{ID: p.ID, Version: p.CurrentVersion})
+	}
+
+	sort.Slice(lock.Packs, func(i, j int) bool {
+		return lock.Packs[i].ID < lock.Packs[j].ID
+	})
+
+	return lock, nil
+}
+
+func (m *MarketplaceModule) Plan(lock PackLockfile) (PackPlan, error) {
+	installed, err := m.ListInstalledPacks()
+	if err != nil {
+		return PackPlan{}, err
+	}
+
+	return planPacks(lock, installed), nil
+}
+
+// planPacks returns the actions bringing the installed packs to the lockfile
+// versions, sorted by pack ID.
+func planPacks(lock PackLockfile, installed []Pack) PackPlan {
+	current := make(map[string]string, len(installed))
+	for _, p := range installed {
+		current[p.ID] = p.CurrentVersion
+	}
+
+	plan := PackPlan{}
+	locked := make(map[string]bool, len(lock.Packs))
+	for _, p := range lock.Packs {
+		locked[p.ID] = true
+
+		version, ok := current[p.ID]
+		switch {
+		case !ok:
+			plan.Actions = append(plan.Actions, PackAction{Type: InstallPackAction, ID: p.ID, To: p.Version})
+		case compareVersions(version, p.Version) < 0:
+			plan.Actions = append(plan.Actions, PackAction{Type: UpgradePackAction, ID: p.ID, From: version, To: p.Version})
+		case compareVersions(version, p.Version) > 0:
+			plan.Actions = append(plan.Actions, PackAction{Type: DowngradePackAction, ID: p.ID, From: version, To: p.Version})
+		}
+	}
+
+	if lock.Prune {
+		for _, p := range installed {
+			if !locked[p.ID] {
+				plan.Actions = append(plan.Actions, PackAction{Type: RemovePackAction, ID: p.ID, From: p.CurrentVersion})
+			}
+		}
+	}
+
+	sort.SliceStable(plan.Actions, func(i, j int) bool {
+		return plan.Actions[i].ID < plan.Actions[j].ID
+	})
+
+	return plan
+}
+
+// mandatoryDependencies returns the sorted IDs of the pack mandatory
+// dependencies, optional ones do not constrain the order of the actions.
+func mandatoryDependencies(p Pack) []string {
+	var deps []string
+	for id, dep := range p.Dependencies {
+		if dep.Mandatory {
+			deps = append(deps, id)
+		}
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// order sorts the actions so that packs are installed after their
+// dependencies and removed before them. Removed packs may be missing from the
+// marketplace so their dependencies are read from the installed packs.
+func (m *MarketplaceModule) order(actions []PackAction) ([]PackAction, error) {
+	installed, err := m.ListInstalledPacks()
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make(map[string][]string, len(actions))
+	for _, p := range installed {
+		deps[p.ID] = mandatoryDependencies(p)
+	}
+
+	for _, a := range actions {
+		if a.Type == RemovePackAction {
+			continue
+		}
+
+		pack, err := m.GetPack(a.ID)
+		if err != nil {
+			return nil, err
+		}
+		deps[a.ID] = mandatoryDependencies(pack)
+	}
+
+	return orderActions(actions, deps), nil
+}
+
+// orderActions sorts the actions following the dependencies of each pack,
+// removals first. Dependency cycles are broken at the first pack visited
+// twice, keeping the plan order for the packs involved.
+func orderActions(actions []PackAction, deps map[string][]string) []PackAction {
+	byID := make(map[string]PackAction, len(actions))
+	for _, a := range actions {
+		byID[a.ID] = a
+	}
+
+	var installs, removals []PackAction
+	visited := make(map[string]bool)
+	var visit func(id string)
+	visit = func(id string) {
+		if visited[id] {
+			return
+		}
+
+		visited[id] = true
+		for _, dep := range deps[id] {
+			if _, ok := byID[dep]; ok {
+				visit(dep)
+			}
+		}
+
+		if a := byID[id]; a.Type == RemovePackAction {
+			removals = append([]PackAction{a}, removals...)
+		} else {
+			installs = append(installs, a)
+		}
+	}
+
+	for _, a := range actions {
+		visit(a.ID)
+	}
+
+	return append(removals, installs...)
+}
+
+// Apply executes the plan in dependency order, calling progress before each
+// action with its position in the plan.
+func (m *MarketplaceModule) Apply(plan PackPlan, progress func(action PackAction, done, total int)) error {
+	actions, err := m.order(plan.Actions)
+	if err != nil {
+		return err
+	}
+
+	for i, a := range actions {
+		if progress != nil {
+			progress(a, i, len(actions))
+		}
+
+		if a.Type == RemovePackAction {
+			err = m.UninstallPack(a.ID)
+		} else {
+			err = m.InstallPacks(PackInstallOptions{IgnoreWarnings: true}, PackVersion{ID: a.ID, Version: a.To})
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "%s pack %q", a.Type, a.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package xsoar
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0", "1.0.0", 0},
+		{"1.0.1", "1.0.0", 1},
+		{"1.0.0", "1.0.1", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.9.9", "1.10.0", -1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.2", "1.2.1", -1},
+		{"1.0.0-beta", "1.0.0-beta", 0},
+		{"1.0.0-alpha", "1.0.0-beta", -1},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParsePackLockfile(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"valid", "packs:\n- id: Base\n  version: 1.2.0\n", true},
+		{"missing version", "packs:\n- id: Base\n", false},
+		{"duplicate pack", "packs:\n- id: Base\n  version: 1.0.0\n- id: Base\n  version: 1.1.0\n", false},
+		{"non-numeric version", "packs:\n- id: Base\n  version: 1.0.0-beta\n", false},
+		{"empty segment", "packs:\n- id: Base\n  version: 1..0\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePackLockfile(strings.NewReader(tt.input))
+			if tt.valid && err != nil {
+				t.Errorf("ParsePackLockfile() = %v, want nil", err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("ParsePackLockfile() = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestPlanPacks(t *testing.T) {
+	installed := []Pack{
+		{ID: "Base", CurrentVersion: "1.2.0"},
+		{ID: "CommonScripts", CurrentVersion: "1.10.0"},
+		{ID: "Custom", CurrentVersion: "1.0.0"},
+		{ID: "VirusTotal", CurrentVersion: "2.0.0"},
+	}
+
+	lock := []PackLock{
+		{ID: "Base", Version: "1.2.0"},
+		{ID: "CommonScripts", Version: "1.9.0"},
+		{ID: "Phishing", Version: "3.0.0"},
+		{ID: "VirusTotal", Version: "2.1.0"},
+	}
+
+	tests := []struct {
+		name  string
+		prune bool
+		want  []PackAction
+	}{
+		{
+			name: "keep unlocked packs",
+			want: []PackAction{
+				{Type: DowngradePackAction, ID: "CommonScripts", From: "1.10.0", To: "1.9.0"},
+				{Type: InstallPackAction, ID: "Phishing", To: "3.0.0"},
+				{Type: UpgradePackAction, ID: "VirusTotal", From: "2.0.0", To: "2.1.0"},
+			},
+		},
+		{
+			name:  "prune unlocked packs",
+			prune: true,
+			want: []PackAction{
+				{Type: DowngradePackAction, ID: "CommonScripts", From: "1.10.0", To: "1.9.0"},
+				{Type: RemovePackAction, ID: "Custom", From: "1.0.0"},
+				{Type: InstallPackAction, ID: "Phishing", To: "3.0.0"},
+				{Type: UpgradePackAction, ID: "VirusTotal", From: "2.0.0", To: "2.1.0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := planPacks(PackLockfile{Prune: tt.prune, Packs: lock}, installed)
+			if !reflect.DeepEqual(plan.Actions, tt.want) {
+				t.Errorf("planPacks() = %+v, want %+v", plan.Actions, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderActions(t *testing.T) {
+	install := func(id string) PackAction { return PackAction{Type: InstallPackAction, ID: id} }
+	remove := func(id string) PackAction { return PackAction{Type: RemovePackAction, ID: id} }
+
+	tests := []struct {
+		name    string
+		actions []PackAction
+		deps    map[string][]string
+		want    []PackAction
+	}{
+		{
+			name:    "no dependencies",
+			actions: []PackAction{install("A"), install("B")},
+			want:    []PackAction{install("A"), install("B")},
+		},
+		{
+			name:    "install dependencies first",
+			actions: []PackAction{install("A"), install("B"), install("C")},
+			deps:    map[string][]string{"A": {"B"}, "B": {"C"}},
+			want:    []PackAction{install("C"), install("B"), install("A")},
+		},
+		{
+			name:    "ignore dependencies outside the plan",
+			actions: []PackAction{install("A")},
+			deps:    map[string][]string{"A": {"Base"}},
+			want:    []PackAction{install("A")},
+		},
+		{
+			name:    "remove dependents first",
+			actions: []PackAction{remove("A"), remove("B")},
+			deps:    map[string][]string{"B": {"A"}},
+			want:    []PackAction{remove("B"), remove("A")},
+		},
+		{
+			name:    "removals before installs",
+			actions: []PackAction{install("A"), remove("B")},
+			want:    []PackAction{remove("B"), install("A")},
+		},
+		{
+			name:    "break cycles",
+			actions: []PackAction{install("A"), install("B"), install("C")},
+			deps:    map[string][]string{"A": {"B"}, "B": {"A"}, "C": {"A"}},
+			want:    []PackAction{install("B"), install("A"), install("C")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orderActions(tt.actions, tt.deps); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("orderActions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
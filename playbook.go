@@ -0,0 +1,420 @@
+package xsoar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type PlaybookTaskType string
+
+const (
+	StartTaskType       PlaybookTaskType = "start"
+	RegularTaskType     PlaybookTaskType = "regular"
+	ConditionTaskType   PlaybookTaskType = "condition"
+	SubPlaybookTaskType PlaybookTaskType = "playbook"
+	TitleTaskType       PlaybookTaskType = "title"
+	CollectionTaskType  PlaybookTaskType = "collection"
+)
+
+type PlaybookTaskDefinition struct {
+	ID           string `json:"id" yaml:"id"`
+	Version      int    `json:"version" yaml:"version"`
+	Name         string `json:"name" yaml:"name"`
+	Description  string `json:"description" yaml:"description"`
+	Type         string `json:"type" yaml:"type"`
+	IsCommand    bool   `json:"iscommand" yaml:"iscommand"`
+	Brand        string `json:"brand" yaml:"brand"`
+	Script       string `json:"script" yaml:"script"`
+	ScriptName   string `json:"scriptName" yaml:"scriptName"`
+	PlaybookName string `json:"playbookName" yaml:"playbookName"`
+	PlaybookID   string `json:"playbookId" yaml:"playbookId"`
+}
+
+type PlaybookCondition struct {
+	Label     string `json:"label" yaml:"label"`
+	Condition any    `json:"condition" yaml:"condition"`
+}
+
+type PlaybookTask struct {
+	ID                        string                 `json:"id" yaml:"id"`
+	TaskID                    string                 `json:"taskid" yaml:"taskid"`
+	Type                      PlaybookTaskType       `json:"type" yaml:"type"`
+	Task                      PlaybookTaskDefinition `json:"task" yaml:"task"`
+	NextTasks                 map[string][]string    `json:"nexttasks" yaml:"nexttasks"`
+	ScriptArguments           map[string]any         `json:"scriptarguments" yaml:"scriptarguments"`
+	Conditions                []PlaybookCondition    `json:"conditions" yaml:"conditions"`
+	SeparateContext           bool                   `json:"separatecontext" yaml:"separatecontext"`
+	ContinueOnError           bool                   `json:"continueonerror" yaml:"continueonerror"`
+	SkipUnavailable           bool                   `json:"skipunavailable" yaml:"skipunavailable"`
+	QuietMode                 int                    `json:"quietmode" yaml:"quietmode"`
+	IsOverSize                bool                   `json:"isoversize" yaml:"isoversize"`
+	IsAutoSwitchedToQuietMode bool                   `json:"isautoswitchedtoquietmode" yaml:"isautoswitchedtoquietmode"`
+	Note                      bool                   `json:"note" yaml:"note"`
+	TimerTriggers             []any                  `json:"timertriggers" yaml:"timertriggers"`
+	IgnoreWorker              bool                   `json:"ignoreworker" yaml:"ignoreworker"`
+	FieldMapping              []any                  `json:"fieldMapping" yaml:"fieldMapping"`
+	Loop                      map[string]any         `json:"loop" yaml:"loop"`
+	Message                   map[string]any         `json:"message" yaml:"message"`
+	Form                      map[string]any         `json:"form" yaml:"form"`
+	View                      string                 `json:"view" yaml:"view"`
+}
+
+type PlaybookIO struct {
+	Key                string `json:"key" yaml:"key"`
+	Value              any    `json:"value" yaml:"value"`
+	Required           bool   `json:"required" yaml:"required"`
+	Description        string `json:"description" yaml:"description"`
+	PlaybookInputQuery any    `json:"playbookInputQuery" yaml:"playbookInputQuery"`
+	ContextPath        string `json:"contextPath" yaml:"contextPath"`
+	Type               string `json:"type" yaml:"type"`
+}
+
+type Playbook struct {
+	ID                string                  `json:"id" yaml:"id"`
+	Version           int                     `json:"version" yaml:"version"`
+	CacheVersn        int                     `json:"cacheVersn" yaml:"-"`
+	Modified          time.Time               `json:"modified" yaml:"-"`
+	Created           time.Time               `json:"created" yaml:"-"`
+	SizeInBytes       int                     `json:"sizeInBytes" yaml:"-"`
+	PackID            string                  `json:"packID" yaml:"-"`
+	PackName          string                  `json:"packName" yaml:"-"`
+	ItemVersion       string                  `json:"itemVersion" yaml:"-"`
+	FromServerVersion string                  `json:"fromServerVersion" yaml:"fromversion"`
+	ToServerVersion   string                  `json:"toServerVersion" yaml:"toversion"`
+	PropagationLabels []string                `json:"propagationLabels" yaml:"-"`
+	Name              string                  `json:"name" yaml:"name"`
+	PrevName          string                  `json:"prevName" yaml:"-"`
+	Description       string                  `json:"description" yaml:"description"`
+	StartTaskID       string                  `json:"startTaskId" yaml:"starttaskid"`
+	Tasks             map[string]PlaybookTask `json:"tasks" yaml:"tasks"`
+	Inputs            []PlaybookIO            `json:"inputs" yaml:"inputs"`
+	Outputs           []PlaybookIO            `json:"outputs" yaml:"outputs"`
+	View              string                  `json:"view" yaml:"view"`
+	Tags              []string                `json:"tags" yaml:"tags"`
+	Tests             []string                `json:"tests" yaml:"tests"`
+	Hidden            bool                    `json:"hidden" yaml:"hidden"`
+	Deprecated        bool                    `json:"deprecated" yaml:"deprecated"`
+	System            bool                    `json:"system" yaml:"system"`
+	Locked            bool                    `json:"locked" yaml:"-"`
+	Quiet             bool                    `json:"quiet" yaml:"quiet"`
+	SortValues        []string                `json:"sortValues" yaml:"-"`
+}
+
+// Next returns the IDs of the tasks following the given task, for every
+// branch label when the task is a condition.
+func (p Playbook) Next(taskID string) []string {
+	var next []string
+	for _, ids := range p.Tasks[taskID].NextTasks {
+		next = append(next, ids...)
+	}
+	sort.Strings(next)
+	return slices.Compact(next)
+}
+
+// Unreachable returns the tasks which cannot be reached from the start task.
+func (p Playbook) Unreachable() []string {
+	visited := make(map[string]bool)
+	queue := []string{p.StartTaskID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		queue = append(queue, p.Next(id)...)
+	}
+
+	var unreachable []string
+	for id := range p.Tasks {
+		if !visited[id] {
+			unreachable = append(unreachable, id)
+		}
+	}
+	sort.Strings(unreachable)
+
+	return unreachable
+}
+
+func (p Playbook) collect(fn func(PlaybookTask) string) []string {
+	var names []string
+	for _, task := range p.Tasks {
+		if name := fn(task); name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return slices.Compact(names)
+}
+
+// Scripts returns the automation scripts run by the playbook tasks.
+func (p Playbook) Scripts() []string {
+	return p.collect(func(t PlaybookTask) string {
+		if t.Type != RegularTaskType && t.Type != ConditionTaskType || t.Task.IsCommand {
+			return ""
+		}
+		if t.Task.ScriptName != "" {
+			return t.Task.ScriptName
+		}
+		return t.Task.Script
+	})
+}
+
+// commandSeparator separates the integration brand from the command name in
+// the script of command tasks.
+const commandSeparator = "|||"
+
+// command returns the brand and the name of the command run by the task, the
+// brand is empty when any integration may run it.
+func (t PlaybookTaskDefinition) command() (string, string) {
+	name := t.ScriptName
+	if name == "" {
+		name = t.Script
+	}
+
+	brand, command, found := strings.Cut(name, commandSeparator)
+	if !found {
+		return t.Brand, name
+	}
+	if brand == "" {
+		brand = t.Brand
+	}
+
+	return brand, command
+}
+
+// Commands returns the integration commands run by the playbook tasks, as
+// "brand|||command" when the task is bound to an integration brand.
+func (p Playbook) Commands() []string {
+	return p.collect(func(t PlaybookTask) string {
+		if !t.Task.IsCommand {
+			return ""
+		}
+
+		brand, command := t.Task.command()
+		if brand == "" || command == "" {
+			return command
+		}
+		return brand + commandSeparator + command
+	})
+}
+
+func (p Playbook) SubPlaybooks() []string {
+	return p.collect(func(t PlaybookTask) string {
+		if t.Type != SubPlaybookTaskType {
+			return ""
+		}
+		if t.Task.PlaybookName != "" {
+			return t.Task.PlaybookName
+		}
+		return t.Task.PlaybookID
+	})
+}
+
+// Check runs static checks on the playbook graph against the known scripts,
+// commands and playbooks and returns the problems found. Commands bound to a
+// brand are only known when listed as "brand|||command".
+func (p Playbook) Check(scripts, commands, playbooks []string) []string {
+	var problems []string
+
+	if _, ok := p.Tasks[p.StartTaskID]; !ok {
+		problems = append(problems, fmt.Sprintf("start task %q does not exist", p.StartTaskID))
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(p.Tasks)) {
+		for _, next := range p.Next(id) {
+			if _, ok := p.Tasks[next]; !ok {
+				problems = append(problems, fmt.Sprintf("task %s points to unknown task %s", id, next))
+			}
+		}
+	}
+
+	for _, id := range p.Unreachable() {
+		problems = append(problems, fmt.Sprintf("task %s is unreachable", id))
+	}
+
+	for _, check := range []struct {
+		kind  string
+		used  []string
+		known []string
+	}{
+		{"script", p.Scripts(), scripts},
+		{"command", p.Commands(), commands},
+		{"playbook", p.SubPlaybooks(), playbooks},
+	} {
+		if check.known == nil {
+			continue
+		}
+		for _, name := range check.used {
+			if !slices.Contains(check.known, name) {
+				problems = append(problems, fmt.Sprintf("%s %q does not exist", check.kind, name))
+			}
+		}
+	}
+
+	return problems
+}
+
+func ParsePlaybook(r io.Reader) (Playbook, error) {
+	var p Playbook
+	return p, yaml.NewDecoder(r).Decode(&p)
+}
+
+func (p Playbook) WriteYAML(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(p); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+type PlaybookSearch struct {
+	Playbooks []Playbook `json:"playbooks"`
+	Total     int        `json:"total"`
+}
+
+type PlaybookModule struct {
+	client *Client
+}
+
+func (m *PlaybookModule) ListPlaybooks() ([]Playbook, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string]string{"query": ""}); err != nil {
+		return nil, err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "playbook/search",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := Decode[PlaybookSearch](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return search.Playbooks, nil
+}
+
+func (m *PlaybookModule) GetPlaybook(id string) (Playbook, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "playbook/"+id,
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Playbook{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Playbook{}, err
+	}
+
+	return Decode[Playbook](resp)
+}
+
+// ExportPlaybook returns the playbook as YAML, the caller is responsible for
+// closing it.
+func (m *PlaybookModule) ExportPlaybook(id string) (io.ReadCloser, error) {
+	req, err := m.client.NewRequest(
+		http.MethodGet, "playbook/"+id+"/yaml",
+		WithHeader("Accept", "application/octet-stream"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (m *PlaybookModule) ImportPlaybook(filename string, content io.Reader) (Playbook, error) {
+	req, err := m.client.NewRequest(
+		http.MethodPost, "playbook/save/yaml",
+		WithFile("file", filename, content),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return Playbook{}, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Playbook{}, err
+	}
+
+	return Decode[Playbook](resp)
+}
+
+func (m *PlaybookModule) DeletePlaybook(id string) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string]string{"id": id}); err != nil {
+		return err
+	}
+
+	req, err := m.client.NewRequest(
+		http.MethodPost, "playbook/delete",
+		WithBody(buf),
+		WithHeader("Content-Type", "application/json"),
+		WithHeader("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.client.Do(req)
+	return err
+}
+
+// CheckPlaybook runs Playbook.Check using the server integration commands and
+// playbooks, and the given scripts.
+func (m *PlaybookModule) CheckPlaybook(p Playbook, scripts []string) ([]string, error) {
+	integrations, err := m.client.Integration.GetIntegrationCommands()
+	if err != nil {
+		return nil, err
+	}
+
+	var commands []string
+	for _, integration := range integrations {
+		for _, command := range integration.Commands {
+			commands = append(commands, command.Name, integration.Name+commandSeparator+command.Name)
+		}
+	}
+
+	playbooks, err := m.ListPlaybooks()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, playbook := range playbooks {
+		names = append(names, playbook.Name, playbook.ID)
+	}
+
+	return p.Check(scripts, commands, names), nil
+}
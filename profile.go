@@ -0,0 +1,175 @@
+package xsoar
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+type AuthMethod string
+
+const (
+	APIKeyAuth AuthMethod = "apikey"
+	BasicAuth  AuthMethod = "basic"
+)
+
+type Profile struct {
+	URL      string     `yaml:"url"`
+	Auth     AuthMethod `yaml:"auth"`
+	APIKey   SecretRef  `yaml:"apiKey"`
+	Username string     `yaml:"username"`
+	Password SecretRef  `yaml:"password"`
+	Insecure bool       `yaml:"insecure"`
+	CAFile   string     `yaml:"caFile"`
+}
+
+type Config struct {
+	Default  string             `yaml:"default"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// ConfigPath returns the path of the profiles file, XSOAR_CONFIG or
+// ~/.xsoar/config.
+func ConfigPath() (string, error) {
+	if path := os.Getenv("XSOAR_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".xsoar", "config"), nil
+}
+
+// LoadConfig reads the profiles file, which must be YAML.
+func LoadConfig(path string) (Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Config{}, err
+	}
+	defer f.Close()
+
+	var config Config
+	if err := yaml.NewDecoder(f).Decode(&config); err != nil {
+		return Config{}, errors.Wrapf(err, "reading %s", path)
+	}
+
+	return config, nil
+}
+
+// Profile returns the named profile, or the one named by XSOAR_PROFILE then
+// the default one when name is empty.
+func (c Config) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = os.Getenv("XSOAR_PROFILE")
+	}
+	if name == "" {
+		name = c.Default
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, errors.Errorf("profile %q not found", name)
+	}
+
+	return profile, nil
+}
+
+func (c *Client) setTLSConfig(config *tls.Config) {
+	c.client.HTTPClient.Transport = &http.Transport{TLSClientConfig: config}
+}
+
+// apply configures the client from the profile, settings also given through
+// the DEMISTO_* environment variables are left as they are. An API key or a
+// username from the environment selects its auth method over the profile
+// one.
+func (p Profile) apply(c *Client) error {
+	if p.URL != "" && os.Getenv("DEMISTO_BASE_URL") == "" {
+		if err := c.setBaseURL(p.URL); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case os.Getenv("DEMISTO_API_KEY") != "":
+	case p.Auth == BasicAuth || p.Auth == "" && p.Username != "":
+		if os.Getenv("DEMISTO_USERNAME") == "" {
+			c.username = p.Username
+		}
+		if os.Getenv("DEMISTO_PASSWORD") == "" {
+			password, err := p.Password.Resolve()
+			if err != nil {
+				return errors.Wrap(err, "profile password")
+			}
+			c.password = password
+		}
+		c.apiKey = ""
+	case p.Auth == APIKeyAuth || p.Auth == "" && !p.APIKey.IsZero():
+		if os.Getenv("DEMISTO_USERNAME") != "" {
+			break
+		}
+		apiKey, err := p.APIKey.Resolve()
+		if err != nil {
+			return errors.Wrap(err, "profile API key")
+		}
+		c.apiKey = apiKey
+	case p.Auth == "":
+	default:
+		return errors.Errorf("unknown auth method %q", p.Auth)
+	}
+
+	// DEMISTO_VERIFY_SSL=false already disabled the verification, any other
+	// value asks for it so only the CA file of the profile is used.
+	verify := os.Getenv("DEMISTO_VERIFY_SSL")
+	insecure := p.Insecure && verify == ""
+	if verify == "false" || !insecure && p.CAFile == "" {
+		return nil
+	}
+
+	config := &tls.Config{InsecureSkipVerify: insecure}
+	if p.CAFile != "" {
+		pem, err := os.ReadFile(p.CAFile)
+		if err != nil {
+			return err
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return errors.Errorf("no certificate found in %s", p.CAFile)
+		}
+	}
+	c.setTLSConfig(config)
+
+	return nil
+}
+
+// WithProfile configures the client from a profile of the config file, see
+// ConfigPath and Config.Profile. DEMISTO_* environment variables take
+// precedence over the profile.
+func WithProfile(name string) ClientOption {
+	return func(c *Client) error {
+		path, err := ConfigPath()
+		if err != nil {
+			return err
+		}
+
+		config, err := LoadConfig(path)
+		if err != nil {
+			return err
+		}
+
+		profile, err := config.Profile(name)
+		if err != nil {
+			return err
+		}
+
+		return profile.apply(c)
+	}
+}
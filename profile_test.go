@@ -0,0 +1,71 @@
+package xsoar
+
+import "testing"
+
+func TestProfileAuthPrecedence(t *testing.T) {
+	basic := Profile{Username: "profile-user", Password: SecretRef{Env: "PROFILE_PASSWORD"}}
+	apiKey := Profile{APIKey: SecretRef{Env: "PROFILE_API_KEY"}}
+
+	tests := []struct {
+		name                     string
+		profile                  Profile
+		env                      map[string]string
+		apiKey, username, passwd string
+	}{
+		{
+			name:     "basic inferred from the username",
+			profile:  basic,
+			username: "profile-user", passwd: "profile-password",
+		},
+		{
+			name:     "password from the environment",
+			profile:  basic,
+			env:      map[string]string{"DEMISTO_PASSWORD": "env-password"},
+			username: "profile-user", passwd: "env-password",
+		},
+		{
+			name:     "username from the environment",
+			profile:  basic,
+			env:      map[string]string{"DEMISTO_USERNAME": "env-user"},
+			username: "env-user", passwd: "profile-password",
+		},
+		{
+			name:    "API key from the environment",
+			profile: basic,
+			env:     map[string]string{"DEMISTO_API_KEY": "env-key"},
+			apiKey:  "env-key",
+		},
+		{
+			name:    "API key profile",
+			profile: apiKey,
+			apiKey:  "profile-key",
+		},
+		{
+			name:     "basic auth from the environment",
+			profile:  apiKey,
+			env:      map[string]string{"DEMISTO_USERNAME": "env-user", "DEMISTO_PASSWORD": "env-password"},
+			username: "env-user", passwd: "env-password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"DEMISTO_API_KEY", "DEMISTO_USERNAME", "DEMISTO_PASSWORD", "DEMISTO_VERIFY_SSL"} {
+				t.Setenv(key, tt.env[key])
+			}
+			t.Setenv("PROFILE_PASSWORD", "profile-password")
+			t.Setenv("PROFILE_API_KEY", "profile-key")
+
+			c := &Client{}
+			c.setDefaultConfig()
+			if err := tt.profile.apply(c); err != nil {
+				t.Fatal(err)
+			}
+
+			if c.apiKey != tt.apiKey || c.username != tt.username || c.password != tt.passwd {
+				t.Errorf("apiKey, username, password = %q, %q, %q, want %q, %q, %q",
+					c.apiKey, c.username, c.password, tt.apiKey, tt.username, tt.passwd)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package xsoar
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// AllPropagationLabel propagates an item to every tenant account.
+const AllPropagationLabel = "all"
+
+type PropagatedItemKind string
+
+const (
+	InstancePropagatedItem    PropagatedItemKind = "instance"
+	IntegrationPropagatedItem PropagatedItemKind = "integration"
+	CredentialPropagatedItem  PropagatedItemKind = "credential"
+	ListPropagatedItem        PropagatedItemKind = "list"
+)
+
+type PropagatedItem struct {
+	Kind   PropagatedItemKind `json:"kind"`
+	ID     string             `json:"id"`
+	Name   string             `json:"name"`
+	Labels []string           `json:"labels"`
+}
+
+// propagatedItems returns the items of the main account carrying
+// propagation labels.
+func (m *AccountModule) propagatedItems() ([]PropagatedItem, error) {
+	c := m.main()
+	var items []PropagatedItem
+
+	search, err := c.Integration.SearchIntegrations(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range search.Instances {
+		if len(i.PropagationLabels) > 0 {
+			items = append(items, PropagatedItem{InstancePropagatedItem, i.ID, i.Name, i.PropagationLabels})
+		}
+	}
+	for _, i := range search.Configurations {
+		if len(i.PropagationLabels) > 0 {
+			items = append(items, PropagatedItem{IntegrationPropagatedItem, i.ID, i.Name, i.PropagationLabels})
+		}
+	}
+
+	credentials, err := c.Integration.ListCredentials()
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range credentials.Credentials {
+		if len(i.PropagationLabels) > 0 {
+			items = append(items, PropagatedItem{CredentialPropagatedItem, i.ID, i.Name, i.PropagationLabels})
+		}
+	}
+
+	lists, err := c.List.GetLists()
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range lists {
+		if len(i.PropagationLabels) > 0 {
+			items = append(items, PropagatedItem{ListPropagatedItem, i.ID, i.Name, i.PropagationLabels})
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Kind != items[j].Kind {
+			return items[i].Kind < items[j].Kind
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	return items, nil
+}
+
+// LabelsInUse returns the items of the main account by propagation label.
+func (m *AccountModule) LabelsInUse() (map[string][]PropagatedItem, error) {
+	items, err := m.propagatedItems()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string][]PropagatedItem)
+	for _, item := range items {
+		for _, label := range item.Labels {
+			labels[label] = append(labels[label], item)
+		}
+	}
+
+	return labels, nil
+}
+
+// LabelTargets returns the tenant accounts targeted by each label in use.
+func (m *AccountModule) LabelTargets() (map[string][]string, error) {
+	labels, err := m.LabelsInUse()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := m.ListAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string][]string, len(labels))
+	for label := range labels {
+		targets[label] = []string{}
+		for _, account := range accounts {
+			if label == AllPropagationLabel || slices.Contains(account.PropagationLabels, label) {
+				targets[label] = append(targets[label], accountName(account.Name))
+			}
+		}
+		sort.Strings(targets[label])
+	}
+
+	return targets, nil
+}
+
+// PreviewAccount returns the items of the main account which would be
+// propagated to the tenant on the next sync.
+func (m *AccountModule) PreviewAccount(name string) ([]PropagatedItem, error) {
+	accounts, err := m.ListAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	idx := slices.IndexFunc(accounts, func(a Account) bool {
+		return accountName(a.Name) == accountName(name)
+	})
+	if idx < 0 {
+		return nil, errors.Errorf("account %q not found", name)
+	}
+	account := accounts[idx]
+
+	items, err := m.propagatedItems()
+	if err != nil {
+		return nil, err
+	}
+
+	var preview []PropagatedItem
+	for _, item := range items {
+		for _, label := range item.Labels {
+			if label == AllPropagationLabel || slices.Contains(account.PropagationLabels, label) {
+				preview = append(preview, item)
+				break
+			}
+		}
+	}
+
+	return preview, nil
+}
+
+func mergeLabels(current, labels []string) []string {
+	merged := slices.Clone(current)
+	for _, label := range labels {
+		if !slices.Contains(merged, label) {
+			merged = append(merged, label)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// AssignLabelsToInstances adds the labels to the instances of the main
+// account, the labels already set are kept.
+func (m *AccountModule) AssignLabelsToInstances(labels []string, ids ...string) error {
+	c := m.main()
+
+	for _, id := range ids {
+		_, err := c.Integration.updateInstance(id, func(u *IntegrationInstanceUpsert) error {
+			u.PropagationLabels = mergeLabels(u.PropagationLabels, labels)
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "instance %q", id)
+		}
+	}
+
+	return nil
+}
+
+// AssignLabelsToCredentials adds the labels to the credentials of the main
+// account, the labels already set are kept. No secret is sent, the stored
+// secret flags are round-tripped from the listed credentials.
+func (m *AccountModule) AssignLabelsToCredentials(labels []string, ids ...string) error {
+	c := m.main()
+
+	search, err := c.Integration.ListCredentials()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		idx := slices.IndexFunc(search.Credentials, func(cr Credential) bool { return cr.ID == id })
+		if idx < 0 {
+			return errors.Errorf("credential %q not found", id)
+		}
+		credential := search.Credentials[idx]
+
+		upsert := credential.ToUpsert()
+		upsert.PropagationLabels = mergeLabels(credential.PropagationLabels, labels)
+
+		_, err := c.Integration.UpsertCredential(upsert)
+		if err != nil {
+			return errors.Wrapf(err, "credential %q", credential.Name)
+		}
+	}
+
+	return nil
+}
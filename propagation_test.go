@@ -0,0 +1,41 @@
+package xsoar
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPropagationUsesMainAccount(t *testing.T) {
+	c, paths := recordPaths(t, map[string]string{
+		"POST /settings/integration/search": `{"instances":[{"id":"i1","name":"instance","brand":"brand"}]}`,
+		"GET /integration/instances":        `[{"id":"i1","name":"instance","brand":"brand"}]`,
+		"PUT /settings/integration":         `{"id":"i1","name":"instance","brand":"brand"}`,
+		"POST /settings/credentials":        `{"credentials":[{"id":"c1","name":"credential"}],"total":1}`,
+		"PUT /settings/credentials":         `{"id":"c1","name":"credential"}`,
+		"GET /lists":                        `[]`,
+	})
+	scoped := c.ForAccount("tenant")
+
+	for name, fn := range map[string]func() error{
+		"LabelsInUse": func() error {
+			_, err := scoped.Account.LabelsInUse()
+			return err
+		},
+		"AssignLabelsToInstances": func() error {
+			return scoped.Account.AssignLabelsToInstances([]string{"emea"}, "i1")
+		},
+		"AssignLabelsToCredentials": func() error {
+			return scoped.Account.AssignLabelsToCredentials([]string{"emea"}, "c1")
+		},
+	} {
+		if err := fn(); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+
+	for _, path := range paths() {
+		if strings.Contains(path, accountPrefix) {
+			t.Errorf("request %q sent to the tenant account", path)
+		}
+	}
+}
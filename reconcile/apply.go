@@ -0,0 +1,217 @@
+package reconcile
+
+import (
+	"encoding/json"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+	"github.com/pkg/errors"
+)
+
+func resolveCredential(c Credential) (xsoar.CredentialUpsert, error) {
+	upsert := c.CredentialUpsert
+
+	if c.PasswordRef != nil {
+		password, err := c.PasswordRef.Resolve()
+		if err != nil {
+			return xsoar.CredentialUpsert{}, err
+		}
+		upsert.Password = password
+	}
+
+	if c.SSHKeyRef != nil {
+		key, err := c.SSHKeyRef.Resolve()
+		if err != nil {
+			return xsoar.CredentialUpsert{}, err
+		}
+		upsert.SSHKey = key
+	}
+
+	return upsert, nil
+}
+
+// overlay sets the fields given by the desired instance on the base upsert,
+// the params of the base are left to the caller.
+func overlay(base xsoar.IntegrationInstanceUpsert, desired Instance) (xsoar.IntegrationInstanceUpsert, error) {
+	fields, err := upsertFields(base)
+	if err != nil {
+		return xsoar.IntegrationInstanceUpsert{}, err
+	}
+
+	set, err := desired.fields()
+	if err != nil {
+		return xsoar.IntegrationInstanceUpsert{}, err
+	}
+
+	for key, value := range set {
+		if value == nil {
+			delete(fields, key)
+		} else {
+			fields[key] = value
+		}
+	}
+	delete(fields, "data")
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return xsoar.IntegrationInstanceUpsert{}, err
+	}
+
+	var upsert xsoar.IntegrationInstanceUpsert
+	return upsert, json.Unmarshal(data, &upsert)
+}
+
+// resolveInstance merges the desired instance on top of the live one, so that
+// fields and params absent from the state and stored secrets are kept.
+func resolveInstance(desired Instance, live *xsoar.IntegrationInstance) (xsoar.IntegrationInstanceUpsert, error) {
+	upsert := desired.IntegrationInstanceUpsert
+	data := desired.Data
+
+	if live != nil {
+		base, err := live.ToUpsert()
+		if err != nil {
+			return xsoar.IntegrationInstanceUpsert{}, err
+		}
+
+		if upsert, err = overlay(base, desired); err != nil {
+			return xsoar.IntegrationInstanceUpsert{}, err
+		}
+
+		base.KeepSecrets()
+		data = base.Data
+
+		for _, d := range desired.Data {
+			found := false
+			for i := range data {
+				if data[i].Name == d.Name {
+					data[i].Value, data[i].Hasvalue, found = d.Value, true, true
+				}
+			}
+			if !found {
+				data = append(data, d)
+			}
+		}
+	}
+
+	for name, ref := range desired.SecretParams {
+		secret, err := ref.Resolve()
+		if err != nil {
+			return xsoar.IntegrationInstanceUpsert{}, errors.Wrapf(err, "param %q", name)
+		}
+
+		found := false
+		for i := range data {
+			if data[i].Name != name {
+				continue
+			}
+			found = true
+			data[i].Hasvalue = true
+
+			if data[i].Type != xsoar.AuthenticationParamType {
+				data[i].Value = secret
+				continue
+			}
+
+			creds := xsoar.InstanceCredentials{Password: secret, PasswordChanged: true}
+			switch v := data[i].Value.(type) {
+			case xsoar.InstanceCredentials:
+				creds.Identifier = v.Identifier
+			case map[string]any:
+				creds.Identifier, _ = v["identifier"].(string)
+			}
+			data[i].Value = creds
+		}
+
+		if !found {
+			return xsoar.IntegrationInstanceUpsert{}, errors.Errorf("secret param %q is not set on the instance", name)
+		}
+	}
+
+	upsert.Data = data
+	return upsert, nil
+}
+
+func (r *Reconciler) applyChange(c Change) error {
+	switch c.Kind {
+	case RoleKind:
+		if c.Action == DeleteAction {
+			_, err := r.client.Role.DeleteRole(c.live.(xsoar.Role).ID)
+			return err
+		}
+
+		role := c.desired.(xsoar.Role)
+		if live, ok := c.live.(xsoar.Role); ok {
+			role.ID, role.Version = live.ID, live.Version
+		}
+		_, err := r.client.Role.UpsertRole(role)
+		return err
+
+	case CredentialKind:
+		if c.Action == DeleteAction {
+			return r.client.Integration.DeleteCredential(c.live.(xsoar.Credential).ID)
+		}
+
+		credential, err := resolveCredential(c.desired.(Credential))
+		if err != nil {
+			return err
+		}
+		if live, ok := c.live.(xsoar.Credential); ok {
+			credential.ID, credential.Version = live.ID, live.Version
+			credential.HasPassword = credential.Password == "" && live.HasPassword
+			credential.HasCertificate = credential.SSHKey == "" && live.HasCertificate
+			credential.HasCertificatePass = credential.HasCertificate && live.HasCertificatePass
+		}
+		_, err = r.client.Integration.UpsertCredential(credential)
+		return err
+
+	case InstanceKind:
+		if c.Action == DeleteAction {
+			return r.client.Integration.DeleteInstance(c.live.(xsoar.IntegrationInstance).ID)
+		}
+
+		var live *xsoar.IntegrationInstance
+		if instance, ok := c.live.(xsoar.IntegrationInstance); ok {
+			live = &instance
+		}
+
+		instance, err := resolveInstance(c.desired.(Instance), live)
+		if err != nil {
+			return err
+		}
+		_, err = r.client.Integration.UpsertInstance(instance)
+		return err
+	}
+
+	return errors.Errorf("unsupported change kind %q", c.Kind)
+}
+
+// Apply executes the plan. Sysconfig changes are sent in a single update
+// after the other changes.
+func (r *Reconciler) Apply(plan Plan, opt ApplyOptions) error {
+	sysconfig := make(map[string]string)
+
+	for _, c := range plan.Changes {
+		if opt.Progress != nil {
+			opt.Progress(c)
+		}
+
+		if c.Kind == SysConfigKind {
+			sysconfig[c.Name] = c.desired.(string)
+			continue
+		}
+
+		if opt.DryRun {
+			continue
+		}
+
+		if err := r.applyChange(c); err != nil {
+			return errors.Wrapf(err, "%s %s %q", c.Action, c.Kind, c.Name)
+		}
+	}
+
+	if opt.DryRun || len(sysconfig) == 0 {
+		return nil
+	}
+
+	_, err := r.client.Server.UpdateConfig(xsoar.SystemConfigUpdate{Data: sysconfig, Version: plan.configVersion})
+	return errors.Wrap(err, "update sysconfig")
+}
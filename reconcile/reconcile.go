@@ -0,0 +1,398 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+	"github.com/pkg/errors"
+)
+
+type Kind string
+
+const (
+	RoleKind       Kind = "role"
+	CredentialKind Kind = "credential"
+	InstanceKind   Kind = "instance"
+	SysConfigKind  Kind = "sysconfig"
+)
+
+type Action string
+
+const (
+	CreateAction Action = "create"
+	UpdateAction Action = "update"
+	DeleteAction Action = "delete"
+)
+
+type Change struct {
+	Kind   Kind     `json:"kind"`
+	Action Action   `json:"action"`
+	Name   string   `json:"name"`
+	Fields []string `json:"fields,omitempty"`
+
+	desired any
+	live    any
+}
+
+func (c Change) String() string {
+	symbol := map[Action]string{CreateAction: "+", UpdateAction: "~", DeleteAction: "-"}[c.Action]
+	if len(c.Fields) == 0 {
+		return fmt.Sprintf("%s %s %s", symbol, c.Kind, c.Name)
+	}
+	return fmt.Sprintf("%s %s %s (%s)", symbol, c.Kind, c.Name, strings.Join(c.Fields, ", "))
+}
+
+type Plan struct {
+	Changes []Change `json:"changes"`
+
+	configVersion int
+}
+
+func (p Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p Plan) String() string {
+	var b strings.Builder
+	for _, c := range p.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+type ApplyOptions struct {
+	// Only report the changes through Progress
+	DryRun bool
+
+	// Called before each change is applied
+	Progress func(Change)
+}
+
+type Reconciler struct {
+	client *xsoar.Client
+}
+
+func New(client *xsoar.Client) *Reconciler {
+	return &Reconciler{client: client}
+}
+
+func sameStrings(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// sameJSON compares two JSON values regardless of their formatting, a
+// missing value equals null.
+func sameJSON(a, b json.RawMessage) (bool, error) {
+	var va, vb any
+	if len(a) > 0 {
+		if err := json.Unmarshal(a, &va); err != nil {
+			return false, err
+		}
+	}
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &vb); err != nil {
+			return false, err
+		}
+	}
+	return reflect.DeepEqual(va, vb), nil
+}
+
+// normalize formats param values so that "true" and true, or a comma
+// separated string and a list, compare equal.
+func normalize(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ",")
+	case []any:
+		parts := make([]string, len(v))
+		for i, p := range v {
+			parts[i] = normalize(p)
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (r *Reconciler) Plan(state State) (Plan, error) {
+	var plan Plan
+
+	steps := []func(State, *Plan) error{r.planRoles, r.planCredentials, r.planInstances, r.planSysConfig}
+	for _, step := range steps {
+		if err := step(state, &plan); err != nil {
+			return Plan{}, err
+		}
+	}
+
+	return plan, nil
+}
+
+func (r *Reconciler) planRoles(state State, plan *Plan) error {
+	roles, err := r.client.Role.GetRoles()
+	if err != nil {
+		return err
+	}
+
+	live := make(map[string]xsoar.Role, len(roles))
+	for _, role := range roles {
+		live[role.Name] = role
+	}
+
+	desired := make(map[string]bool, len(state.Roles))
+	for _, role := range state.Roles {
+		desired[role.Name] = true
+
+		current, ok := live[role.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Kind: RoleKind, Action: CreateAction, Name: role.Name, desired: role})
+			continue
+		}
+
+		var fields []string
+		for _, f := range []struct {
+			name string
+			a, b []string
+		}{
+			{"permissions", role.Permissions, current.Permissions},
+			{"nestedRoles", role.NestedRoles, current.NestedRoles},
+			{"pagesAccess", role.PagesAccess, current.PagesAccess},
+			{"adGroups", role.ADGroups, current.ADGroups},
+			{"samlGroups", role.SamlGroups, current.SamlGroups},
+			{"defaultDashboards", role.DefaultDashboards, current.DefaultDashboards},
+		} {
+			if !sameStrings(f.a, f.b) {
+				fields = append(fields, f.name)
+			}
+		}
+		if !slices.Equal(role.Shifts, current.Shifts) {
+			fields = append(fields, "shifts")
+		}
+
+		if len(fields) > 0 {
+			plan.Changes = append(plan.Changes, Change{Kind: RoleKind, Action: UpdateAction, Name: role.Name, Fields: fields, desired: role, live: current})
+		}
+	}
+
+	if state.Prune {
+		for _, role := range roles {
+			if !desired[role.Name] && !role.Locked {
+				plan.Changes = append(plan.Changes, Change{Kind: RoleKind, Action: DeleteAction, Name: role.Name, live: role})
+			}
+		}
+	}
+
+	return nil
+}
+
+// planCredentials compares the credentials metadata, secrets are not
+// readable from the server so changing only a secret is not detected unless
+// State.AlwaysUpdateSecrets is set.
+func (r *Reconciler) planCredentials(state State, plan *Plan) error {
+	search, err := r.client.Integration.ListCredentials()
+	if err != nil {
+		return err
+	}
+
+	live := make(map[string]xsoar.Credential, len(search.Credentials))
+	for _, credential := range search.Credentials {
+		live[credential.Name] = credential
+	}
+
+	desired := make(map[string]bool, len(state.Credentials))
+	for _, credential := range state.Credentials {
+		desired[credential.Name] = true
+
+		current, ok := live[credential.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Kind: CredentialKind, Action: CreateAction, Name: credential.Name, desired: credential})
+			continue
+		}
+
+		var fields []string
+		if credential.User != current.User {
+			fields = append(fields, "user")
+		}
+		if credential.Workgroup != current.Workgroup {
+			fields = append(fields, "workgroup")
+		}
+		if state.AlwaysUpdateSecrets && (credential.PasswordRef != nil || credential.SSHKeyRef != nil) {
+			fields = append(fields, "secrets")
+		}
+
+		if len(fields) > 0 {
+			plan.Changes = append(plan.Changes, Change{Kind: CredentialKind, Action: UpdateAction, Name: credential.Name, Fields: fields, desired: credential, live: current})
+		}
+	}
+
+	if state.Prune {
+		for _, credential := range search.Credentials {
+			if !desired[credential.Name] && !credential.Locked {
+				plan.Changes = append(plan.Changes, Change{Kind: CredentialKind, Action: DeleteAction, Name: credential.Name, live: credential})
+			}
+		}
+	}
+
+	return nil
+}
+
+// instanceFields compares the fields given by the desired instance with its
+// live version, secret params are skipped as the server only returns them
+// masked.
+func instanceFields(desired Instance, current xsoar.IntegrationInstance) ([]string, error) {
+	set, err := desired.fields()
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := current.ToUpsert()
+	if err != nil {
+		return nil, err
+	}
+
+	liveFields, err := upsertFields(live)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	for _, key := range slices.Sorted(maps.Keys(set)) {
+		switch key {
+		case "name":
+			continue
+		case "integrationLogLevel":
+			if level := desired.IntegrationLogLevel; level != live.IntegrationLogLevel && !(level.IsOff() && live.IntegrationLogLevel.IsOff()) {
+				fields = append(fields, key)
+			}
+		case "PropagationLabels":
+			if !sameStrings(desired.PropagationLabels, live.PropagationLabels) {
+				fields = append(fields, "propagationLabels")
+			}
+		default:
+			same, err := sameJSON(set[key], liveFields[key])
+			if err != nil {
+				return nil, errors.Wrapf(err, "field %q", key)
+			}
+			if !same {
+				fields = append(fields, key)
+			}
+		}
+	}
+
+	for _, d := range desired.Data {
+		if _, secret := desired.SecretParams[d.Name]; secret {
+			continue
+		}
+
+		param, ok := current.Param(d.Name)
+		if !ok {
+			fields = append(fields, "data."+d.Name)
+			continue
+		}
+
+		switch param.Type {
+		case xsoar.EncryptedParamType, xsoar.EncryptedLongTextParamType, xsoar.AuthenticationParamType:
+			continue
+		}
+
+		value, err := param.DecodedValue()
+		if err != nil {
+			return nil, err
+		}
+
+		if normalize(d.Value) != normalize(value) {
+			fields = append(fields, "data."+d.Name)
+		}
+	}
+
+	return fields, nil
+}
+
+func (r *Reconciler) planInstances(state State, plan *Plan) error {
+	instances, err := r.client.Integration.GetInstances()
+	if err != nil {
+		return err
+	}
+
+	live := make(map[string]xsoar.IntegrationInstance, len(instances))
+	for _, instance := range instances {
+		live[instance.Name] = instance
+	}
+
+	desired := make(map[string]bool, len(state.Instances))
+	for _, instance := range state.Instances {
+		desired[instance.Name] = true
+
+		current, ok := live[instance.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Kind: InstanceKind, Action: CreateAction, Name: instance.Name, desired: instance})
+			continue
+		}
+
+		fields, err := instanceFields(instance, current)
+		if err != nil {
+			return errors.Wrapf(err, "instance %q", instance.Name)
+		}
+		if state.AlwaysUpdateSecrets && len(instance.SecretParams) > 0 {
+			fields = append(fields, "secrets")
+		}
+
+		if len(fields) > 0 {
+			plan.Changes = append(plan.Changes, Change{Kind: InstanceKind, Action: UpdateAction, Name: instance.Name, Fields: fields, desired: instance, live: current})
+		}
+	}
+
+	if state.Prune {
+		for _, instance := range instances {
+			if !desired[instance.Name] && !instance.IsBuiltin && !instance.IsSystemIntegration {
+				plan.Changes = append(plan.Changes, Change{Kind: InstanceKind, Action: DeleteAction, Name: instance.Name, live: instance})
+			}
+		}
+	}
+
+	return nil
+}
+
+// planSysConfig only creates or updates keys, the keys missing from the state
+// are left untouched even when pruning.
+func (r *Reconciler) planSysConfig(state State, plan *Plan) error {
+	if len(state.SysConfig) == 0 {
+		return nil
+	}
+
+	config, err := r.client.Server.GetConfig()
+	if err != nil {
+		return err
+	}
+	plan.configVersion = config.Version
+
+	keys := make([]string, 0, len(state.SysConfig))
+	for key := range state.SysConfig {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := state.SysConfig[key]
+		current, ok := config.SysConfig[key]
+		switch {
+		case !ok:
+			plan.Changes = append(plan.Changes, Change{Kind: SysConfigKind, Action: CreateAction, Name: key, desired: value})
+		case current != value:
+			plan.Changes = append(plan.Changes, Change{Kind: SysConfigKind, Action: UpdateAction, Name: key, desired: value, live: current})
+		}
+	}
+
+	return nil
+}
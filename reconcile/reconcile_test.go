@@ -0,0 +1,96 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+func TestInstanceMerge(t *testing.T) {
+	live := xsoar.IntegrationInstance{
+		ID:                  "1",
+		Name:                "vt",
+		Brand:               "VirusTotal",
+		Version:             3,
+		Enabled:             true,
+		Engine:              "engine",
+		CanSample:           true,
+		DebugMode:           true,
+		IntegrationLogLevel: xsoar.LogLevelOff,
+		Data: []xsoar.InstanceIntegrationData{
+			{Name: "url", Type: xsoar.ShortTextParamType, Value: json.RawMessage(`"https://vt"`), Hasvalue: true},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		state  string
+		fields []string
+		want   func(*xsoar.IntegrationInstanceUpsert)
+	}{
+		{
+			name:  "keep fields missing from the state",
+			state: "name: vt\nbrand: VirusTotal\nenabled: true\n",
+		},
+		{
+			name:   "update fields given by the state",
+			state:  "name: vt\nbrand: VirusTotal\nenabled: false\ndebugMode: false\nengine: \"\"\n",
+			fields: []string{"debugMode", "enabled", "engine"},
+			want: func(u *xsoar.IntegrationInstanceUpsert) {
+				u.Enabled, u.DebugMode, u.Engine = false, false, ""
+			},
+		},
+		{
+			name:  "empty log level is off",
+			state: "name: vt\nbrand: VirusTotal\nintegrationLogLevel: \"\"\n",
+			want: func(u *xsoar.IntegrationInstanceUpsert) {
+				u.IntegrationLogLevel = ""
+			},
+		},
+		{
+			name:   "update params",
+			state:  "name: vt\nbrand: VirusTotal\ndata:\n- name: url\n  type: 0\n  value: https://other\n",
+			fields: []string{"data.url"},
+			want: func(u *xsoar.IntegrationInstanceUpsert) {
+				u.Data[0].Value = "https://other"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := LoadState(strings.NewReader("instances:\n- " + strings.ReplaceAll(tt.state, "\n", "\n  ")))
+			if err != nil {
+				t.Fatal(err)
+			}
+			desired := state.Instances[0]
+
+			fields, err := instanceFields(desired, live)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("instanceFields() = %v, want %v", fields, tt.fields)
+			}
+
+			got, err := resolveInstance(desired, &live)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want, err := live.ToUpsert()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want != nil {
+				tt.want(&want)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("resolveInstance() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"io"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+type Credential struct {
+	xsoar.CredentialUpsert
+	PasswordRef *xsoar.SecretRef `json:"passwordRef,omitempty"`
+	SSHKeyRef   *xsoar.SecretRef `json:"sshKeyRef,omitempty"`
+}
+
+// Instance is the desired configuration of an integration instance. Fields
+// missing from the state keep their live value, instances built in code set
+// every field.
+type Instance struct {
+	xsoar.IntegrationInstanceUpsert
+
+	// Secret params by name, for authentication params the secret is used as
+	// the password of the value. Instances created from the state must also
+	// list each secret param in Data with its type.
+	SecretParams map[string]xsoar.SecretRef `json:"secretParams,omitempty"`
+
+	// Upsert fields given by the state, nil when built in code
+	set map[string]bool
+}
+
+// UnmarshalJSON decodes the secret params next to the upsert, which has its
+// own decoding, and records the fields given.
+func (i *Instance) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &i.IntegrationInstanceUpsert); err != nil {
+		return err
+	}
+
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	i.set = make(map[string]bool, len(keys))
+	for key := range keys {
+		i.set[key] = true
+	}
+
+	var secrets struct {
+		SecretParams map[string]xsoar.SecretRef `json:"secretParams"`
+	}
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return err
+	}
+	i.SecretParams = secrets.SecretParams
+
+	return nil
+}
+
+// upsertFields returns the JSON fields of the upsert.
+func upsertFields(u xsoar.IntegrationInstanceUpsert) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(u)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	return fields, json.Unmarshal(data, &fields)
+}
+
+// fields returns the upsert fields given by the desired instance, except the
+// identifiers and the params which are merged separately.
+func (i Instance) fields() (map[string]json.RawMessage, error) {
+	fields, err := upsertFields(i.IntegrationInstanceUpsert)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.set != nil {
+		for key := range i.set {
+			if _, ok := fields[key]; !ok {
+				// omitted as empty when encoding
+				fields[key] = nil
+			}
+		}
+		for key := range fields {
+			if !i.set[key] {
+				delete(fields, key)
+			}
+		}
+	}
+
+	for _, key := range []string{"id", "version", "prevName", "data", "secretParams"} {
+		delete(fields, key)
+	}
+
+	return fields, nil
+}
+
+type State struct {
+	// Delete roles, credentials and instances missing from the state
+	Prune bool `json:"prune"`
+
+	// Secrets are not readable from the server so a changed secret ref is not
+	// detected, this updates every credential and instance with secret refs
+	AlwaysUpdateSecrets bool `json:"alwaysUpdateSecrets"`
+
+	Roles       []xsoar.Role      `json:"roles"`
+	Credentials []Credential      `json:"credentials"`
+	Instances   []Instance        `json:"instances"`
+	SysConfig   map[string]string `json:"sysconfig"`
+}
+
+// LoadState reads a desired state document in YAML or JSON. Keys are the JSON
+// names of the client types.
+func LoadState(r io.Reader) (State, error) {
+	var state State
+	return state, xsoar.DecodeDocument(r, &state)
+}
@@ -0,0 +1,47 @@
+package xsoar
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SecretRef points at a secret stored outside of a configuration file. Exactly
+// one source must be set.
+type SecretRef struct {
+	Env     string `json:"env,omitempty" yaml:"env,omitempty"`
+	File    string `json:"file,omitempty" yaml:"file,omitempty"`
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+func (s SecretRef) IsZero() bool {
+	return s.Env == "" && s.File == "" && s.Command == ""
+}
+
+// Resolve returns the secret with surrounding whitespace removed.
+func (s SecretRef) Resolve() (string, error) {
+	switch {
+	case s.Env != "" && s.File == "" && s.Command == "":
+		value, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return "", errors.Errorf("environment variable %s is not set", s.Env)
+		}
+		return strings.TrimSpace(value), nil
+	case s.File != "" && s.Env == "" && s.Command == "":
+		data, err := os.ReadFile(s.File)
+		if err != nil {
+			return "", errors.Wrap(err, "reading secret file")
+		}
+		return strings.TrimSpace(string(data)), nil
+	case s.Command != "" && s.Env == "" && s.File == "":
+		out, err := exec.Command("sh", "-c", s.Command).Output()
+		if err != nil {
+			return "", errors.Wrapf(err, "running secret command %q", s.Command)
+		}
+		return strings.TrimSpace(string(out)), nil
+	default:
+		return "", errors.New("secret reference must set exactly one of env, file or command")
+	}
+}
@@ -0,0 +1,238 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+type ChangeType string
+
+const (
+	AddedChange   ChangeType = "added"
+	RemovedChange ChangeType = "removed"
+	ChangedChange ChangeType = "changed"
+)
+
+type Change struct {
+	Section string     `json:"section"`
+	Name    string     `json:"name"`
+	Path    string     `json:"path,omitempty"`
+	Type    ChangeType `json:"type"`
+	Before  any        `json:"before,omitempty"`
+	After   any        `json:"after,omitempty"`
+}
+
+func (c Change) String() string {
+	target := c.Section + " " + c.Name
+	if c.Path != "" {
+		target += " " + c.Path
+	}
+
+	switch c.Type {
+	case AddedChange:
+		return fmt.Sprintf("+ %s: %s", target, format(c.After))
+	case RemovedChange:
+		return fmt.Sprintf("- %s: %s", target, format(c.Before))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", target, format(c.Before), format(c.After))
+	}
+}
+
+func format(v any) string {
+	if v == nil {
+		return "null"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// volatileKeys differ between servers for identical configurations.
+var volatileKeys = []string{
+	"id", "version", "cacheVersn", "modified", "created", "sizeInBytes", "sortValues",
+	"syncHash", "lastLogin", "lastLoginMaster", "servicesID", "longRunningId",
+	"primaryTerm", "sequenceNumber",
+}
+
+func normalize(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	return stripVolatile(generic), nil
+}
+
+func stripVolatile(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for _, key := range volatileKeys {
+			delete(t, key)
+		}
+		for key, value := range t {
+			t[key] = stripVolatile(value)
+		}
+	case []any:
+		for i, value := range t {
+			t[i] = stripVolatile(value)
+		}
+	}
+	return v
+}
+
+func keyed[T any](items []T, name func(T) string) (map[string]any, error) {
+	result := make(map[string]any, len(items))
+	for _, item := range items {
+		n, err := normalize(item)
+		if err != nil {
+			return nil, err
+		}
+		result[name(item)] = n
+	}
+	return result, nil
+}
+
+func diffValues(section, name, path string, a, b any, changes *[]Change) {
+	ma, aIsMap := a.(map[string]any)
+	mb, bIsMap := b.(map[string]any)
+	if aIsMap && bIsMap {
+		keys := make(map[string]bool)
+		for k := range ma {
+			keys[k] = true
+		}
+		for k := range mb {
+			keys[k] = true
+		}
+
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		for _, k := range sorted {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+
+			va, inA := ma[k]
+			vb, inB := mb[k]
+			switch {
+			case !inA:
+				*changes = append(*changes, Change{Section: section, Name: name, Path: child, Type: AddedChange, After: vb})
+			case !inB:
+				*changes = append(*changes, Change{Section: section, Name: name, Path: child, Type: RemovedChange, Before: va})
+			default:
+				diffValues(section, name, child, va, vb, changes)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, Change{Section: section, Name: name, Path: path, Type: ChangedChange, Before: a, After: b})
+	}
+}
+
+func diffSection(section string, a, b map[string]any, changes *[]Change) {
+	names := make(map[string]bool)
+	for n := range a {
+		names[n] = true
+	}
+	for n := range b {
+		names[n] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for n := range names {
+		sorted = append(sorted, n)
+	}
+	sort.Strings(sorted)
+
+	for _, n := range sorted {
+		va, inA := a[n]
+		vb, inB := b[n]
+		switch {
+		case !inA:
+			*changes = append(*changes, Change{Section: section, Name: n, Type: AddedChange, After: vb})
+		case !inB:
+			*changes = append(*changes, Change{Section: section, Name: n, Type: RemovedChange, Before: va})
+		default:
+			diffValues(section, n, "", va, vb, changes)
+		}
+	}
+}
+
+func (s Snapshot) sections() (map[string]map[string]any, error) {
+	sections := make(map[string]map[string]any)
+	var err error
+
+	if sections["role"], err = keyed(s.Roles, func(r xsoar.Role) string { return r.Name }); err != nil {
+		return nil, err
+	}
+	if sections["user"], err = keyed(s.Users, func(u xsoar.User) string { return u.Username }); err != nil {
+		return nil, err
+	}
+	if sections["credential"], err = keyed(s.Credentials, func(c xsoar.Credential) string { return c.Name }); err != nil {
+		return nil, err
+	}
+	if sections["apikey"], err = keyed(s.APIKeys, func(k xsoar.APIKey) string { return k.Name }); err != nil {
+		return nil, err
+	}
+	if sections["instance"], err = keyed(s.Instances, func(i xsoar.IntegrationInstance) string { return i.Name }); err != nil {
+		return nil, err
+	}
+
+	sections["sysconfig"] = make(map[string]any, len(s.SysConfig))
+	for k, v := range s.SysConfig {
+		sections["sysconfig"][k] = v
+	}
+
+	return sections, nil
+}
+
+var sectionOrder = []string{"role", "user", "credential", "apikey", "instance", "sysconfig"}
+
+// Diff returns the changes needed to go from a to b. Items are matched by
+// name and fields which differ between servers, such as IDs and versions,
+// are ignored.
+func Diff(a, b Snapshot) ([]Change, error) {
+	sa, err := a.sections()
+	if err != nil {
+		return nil, err
+	}
+
+	sb, err := b.sections()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for _, section := range sectionOrder {
+		diffSection(section, sa[section], sb[section], &changes)
+	}
+
+	return changes, nil
+}
+
+func FormatChanges(changes []Change) string {
+	var b strings.Builder
+	for _, c := range changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
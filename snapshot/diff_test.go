@@ -0,0 +1,75 @@
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+func TestDiff(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		a, b Snapshot
+		want []Change
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "ignore volatile fields",
+			a: Snapshot{Credentials: []xsoar.Credential{
+				{ID: "1", Version: 1, CacheVersn: 1, Created: now, Modified: now, SizeInBytes: 10, Name: "svc", User: "admin"},
+			}},
+			b: Snapshot{Credentials: []xsoar.Credential{
+				{ID: "2", Version: 7, CacheVersn: 3, Created: now.Add(time.Hour), Modified: now.Add(time.Hour), SizeInBytes: 20, Name: "svc", User: "admin"},
+			}},
+		},
+		{
+			name: "changed field",
+			a:    Snapshot{Credentials: []xsoar.Credential{{ID: "1", Name: "svc", User: "admin"}}},
+			b:    Snapshot{Credentials: []xsoar.Credential{{ID: "2", Name: "svc", User: "root"}}},
+			want: []Change{
+				{Section: "credential", Name: "svc", Path: "user", Type: ChangedChange, Before: "admin", After: "root"},
+			},
+		},
+		{
+			name: "added and removed items",
+			a:    Snapshot{SysConfig: map[string]string{"a": "1", "b": "2"}},
+			b:    Snapshot{SysConfig: map[string]string{"b": "2", "c": "3"}},
+			want: []Change{
+				{Section: "sysconfig", Name: "a", Type: RemovedChange, Before: "1"},
+				{Section: "sysconfig", Name: "c", Type: AddedChange, After: "3"},
+			},
+		},
+		{
+			name: "ignore volatile fields of every section",
+			a: Snapshot{
+				SysConfig: map[string]string{"a": "1"},
+				Roles:     []xsoar.Role{{ID: "r1", Name: "Analyst"}},
+			},
+			b: Snapshot{
+				SysConfig: map[string]string{"a": "2"},
+				Roles:     []xsoar.Role{{ID: "r2", Name: "Analyst"}},
+			},
+			want: []Change{
+				{Section: "sysconfig", Name: "a", Type: ChangedChange, Before: "1", After: "2"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+const masked = "*****"
+
+type Snapshot struct {
+	Roles       []xsoar.Role                `json:"roles"`
+	Users       []xsoar.User                `json:"users"`
+	Credentials []xsoar.Credential          `json:"credentials"`
+	APIKeys     []xsoar.APIKey              `json:"apiKeys"`
+	Instances   []xsoar.IntegrationInstance `json:"instances"`
+	SysConfig   map[string]string           `json:"sysconfig"`
+}
+
+// maskInstance hides the encrypted values and drops the integration
+// definition, which belongs to the content rather than the configuration.
+func maskInstance(instance xsoar.IntegrationInstance) xsoar.IntegrationInstance {
+	instance.Configuration = xsoar.Integration{}
+	instance.Password = ""
+
+	for i, d := range instance.Data {
+		switch d.Type {
+		case xsoar.EncryptedParamType, xsoar.EncryptedLongTextParamType:
+			if d.Hasvalue {
+				instance.Data[i].Value = json.RawMessage(`"` + masked + `"`)
+			}
+		case xsoar.AuthenticationParamType:
+			creds, err := d.CredentialsValue()
+			if err != nil {
+				instance.Data[i].Value = json.RawMessage(`"` + masked + `"`)
+				continue
+			}
+			if creds.Password != "" {
+				creds.Password = masked
+			}
+			value, _ := json.Marshal(creds)
+			instance.Data[i].Value = value
+		}
+	}
+
+	if instance.ConfigValues != nil {
+		values := make(map[string]any, len(instance.ConfigValues))
+		for k, v := range instance.ConfigValues {
+			switch xsoar.IntegrationParamType(instance.ConfigTypes[k]) {
+			case xsoar.EncryptedParamType, xsoar.AuthenticationParamType, xsoar.EncryptedLongTextParamType:
+				if v != nil && v != "" {
+					v = masked
+				}
+			}
+			values[k] = v
+		}
+		instance.ConfigValues = values
+	}
+
+	return instance
+}
+
+// Capture reads the configuration of the server into a snapshot sorted by
+// name, volatile fields such as login times are cleared.
+func Capture(client *xsoar.Client) (Snapshot, error) {
+	var s Snapshot
+	var err error
+
+	if s.Roles, err = client.Role.GetRoles(); err != nil {
+		return Snapshot{}, err
+	}
+	sort.Slice(s.Roles, func(i, j int) bool { return s.Roles[i].Name < s.Roles[j].Name })
+
+	if s.Users, err = client.User.GetUsers(); err != nil {
+		return Snapshot{}, err
+	}
+	for i := range s.Users {
+		s.Users[i].LastLogin = time.Time{}
+		s.Users[i].LastLoginMaster = time.Time{}
+		s.Users[i].IsAway = false
+		s.Users[i].PlaygroundId = ""
+		s.Users[i].PlaygroundCleared = false
+	}
+	sort.Slice(s.Users, func(i, j int) bool { return s.Users[i].Username < s.Users[j].Username })
+
+	credentials, err := client.Integration.ListCredentials()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	s.Credentials = credentials.Credentials
+	sort.Slice(s.Credentials, func(i, j int) bool { return s.Credentials[i].Name < s.Credentials[j].Name })
+
+	if s.APIKeys, err = client.Integration.ListAPIKeys(); err != nil {
+		return Snapshot{}, err
+	}
+	sort.Slice(s.APIKeys, func(i, j int) bool { return s.APIKeys[i].Name < s.APIKeys[j].Name })
+
+	instances, err := client.Integration.GetInstances()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	for _, instance := range instances {
+		s.Instances = append(s.Instances, maskInstance(instance))
+	}
+	sort.Slice(s.Instances, func(i, j int) bool { return s.Instances[i].Name < s.Instances[j].Name })
+
+	config, err := client.Server.GetConfig()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	s.SysConfig = config.SysConfig
+
+	return s, nil
+}
+
+func (s Snapshot) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+func Load(r io.Reader) (Snapshot, error) {
+	var s Snapshot
+	return s, json.NewDecoder(r).Decode(&s)
+}
@@ -0,0 +1,75 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"testing"
+
+	xsoar "github.com/MathieuG0/XSOAR-Go-Client"
+)
+
+func TestMaskInstance(t *testing.T) {
+	tests := []struct {
+		name  string
+		param xsoar.InstanceIntegrationData
+		want  string
+	}{
+		{
+			name:  "encrypted",
+			param: xsoar.InstanceIntegrationData{Type: xsoar.EncryptedParamType, Value: json.RawMessage(`"secret"`), Hasvalue: true},
+			want:  `"*****"`,
+		},
+		{
+			name:  "encrypted long text",
+			param: xsoar.InstanceIntegrationData{Type: xsoar.EncryptedLongTextParamType, Value: json.RawMessage(`"secret"`), Hasvalue: true},
+			want:  `"*****"`,
+		},
+		{
+			name:  "credentials",
+			param: xsoar.InstanceIntegrationData{Type: xsoar.AuthenticationParamType, Value: json.RawMessage(`{"identifier":"admin","password":"secret"}`), Hasvalue: true},
+			want:  `{"credential":"","identifier":"admin","password":"*****","passwordChanged":false}`,
+		},
+		{
+			name:  "credential reference",
+			param: xsoar.InstanceIntegrationData{Type: xsoar.AuthenticationParamType, Value: json.RawMessage(`{"credential":"svc","identifier":"","password":""}`), Hasvalue: true},
+			want:  `{"credential":"svc","identifier":"","password":"","passwordChanged":false}`,
+		},
+		{
+			name:  "invalid credentials",
+			param: xsoar.InstanceIntegrationData{Type: xsoar.AuthenticationParamType, Value: json.RawMessage(`"admin:secret"`), Hasvalue: true},
+			want:  `"*****"`,
+		},
+		{
+			name:  "plain text",
+			param: xsoar.InstanceIntegrationData{Type: xsoar.ShortTextParamType, Value: json.RawMessage(`"https://example.com"`), Hasvalue: true},
+			want:  `"https://example.com"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := maskInstance(xsoar.IntegrationInstance{Data: []xsoar.InstanceIntegrationData{tt.param}})
+			if got := string(instance.Data[0].Value); got != tt.want {
+				t.Errorf("maskInstance() value = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskInstanceConfigValues(t *testing.T) {
+	instance := maskInstance(xsoar.IntegrationInstance{
+		Password:     "secret",
+		ConfigValues: map[string]any{"apikey": "secret", "creds": map[string]any{"password": "secret"}, "cert": "secret", "url": "https://example.com", "empty": ""},
+		ConfigTypes:  map[string]int{"apikey": 4, "creds": 9, "cert": 14, "url": 0, "empty": 4},
+	})
+
+	if instance.Password != "" {
+		t.Errorf("Password = %q, want empty", instance.Password)
+	}
+
+	want := map[string]any{"apikey": masked, "creds": masked, "cert": masked, "url": "https://example.com", "empty": ""}
+	for k, v := range want {
+		if instance.ConfigValues[k] != v {
+			t.Errorf("ConfigValues[%q] = %v, want %v", k, instance.ConfigValues[k], v)
+		}
+	}
+}
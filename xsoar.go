@@ -1,7 +1,10 @@
 package xsoar
 
 import (
+	"bytes"
 	"crypto/tls"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
@@ -24,6 +27,12 @@ type Client struct {
 	// Base URL of the XSOAR server
 	baseURL *url.URL
 
+	// Base URL of the main account when the client is scoped to a tenant
+	mainURL *url.URL
+
+	// Tenant account the client is scoped to
+	account string
+
 	// Credentials for basic authentication
 	username, password string
 
@@ -34,10 +43,22 @@ type Client struct {
 	userAgent string
 
 	// API modules
-	Integration *IntegrationModule
-	Role        *RoleModule
-	User        *UserModule
-	Server      *ServerModule
+	Integration   *IntegrationModule
+	Role          *RoleModule
+	User          *UserModule
+	Server        *ServerModule
+	Engine        *EngineModule
+	Classifier    *ClassifierModule
+	IncidentType  *IncidentTypeModule
+	IncidentField *IncidentFieldModule
+	Layout        *LayoutModule
+	Playbook      *PlaybookModule
+	Investigation *InvestigationModule
+	Job           *JobModule
+	Marketplace   *MarketplaceModule
+	Content       *ContentModule
+	List          *ListModule
+	Account       *AccountModule
 }
 
 func NewClient(options ...ClientOption) (*Client, error) {
@@ -54,12 +75,28 @@ func NewClient(options ...ClientOption) (*Client, error) {
 		}
 	}
 
+	c.initModules()
+
+	return c, nil
+}
+
+func (c *Client) initModules() {
 	c.Integration = &IntegrationModule{c}
 	c.Role = &RoleModule{c}
 	c.User = &UserModule{c}
 	c.Server = &ServerModule{c}
-
-	return c, nil
+	c.Engine = &EngineModule{c}
+	c.Classifier = &ClassifierModule{c}
+	c.IncidentType = &IncidentTypeModule{c}
+	c.IncidentField = &IncidentFieldModule{c}
+	c.Layout = &LayoutModule{c}
+	c.Playbook = &PlaybookModule{c}
+	c.Investigation = &InvestigationModule{c}
+	c.Job = &JobModule{c}
+	c.Marketplace = &MarketplaceModule{c}
+	c.Content = &ContentModule{c}
+	c.List = &ListModule{c}
+	c.Account = &AccountModule{c}
 }
 
 func WithBaseURL(baseURL string) ClientOption {
@@ -82,6 +119,15 @@ func WithBasicAuth(username, password string) ClientOption {
 	}
 }
 
+// WithLogger sets the logger of the underlying retryable HTTP client, a nil
+// logger disables logging.
+func WithLogger(logger retryablehttp.Logger) ClientOption {
+	return func(c *Client) error {
+		c.client.Logger = logger
+		return nil
+	}
+}
+
 func WithoutSSLVerify() ClientOption {
 	return func(c *Client) error {
 		c.disableSSLVerify()
@@ -90,10 +136,7 @@ func WithoutSSLVerify() ClientOption {
 }
 
 func (c *Client) disableSSLVerify() {
-	t := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
-	c.client.HTTPClient.Transport = t
+	c.setTLSConfig(&tls.Config{InsecureSkipVerify: true})
 }
 
 func (c *Client) setBaseURL(baseURL string) (err error) {
@@ -143,6 +186,49 @@ func WithBody(rawBody any) RequestOption {
 	}
 }
 
+func WithFile(field, filename string, content io.Reader) RequestOption {
+	return func(req *retryablehttp.Request) error {
+		buf := new(bytes.Buffer)
+		w := multipart.NewWriter(buf)
+
+		part, err := w.CreateFormFile(field, filename)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(part, content); err != nil {
+			return err
+		}
+
+		if err := w.Close(); err != nil {
+			return err
+		}
+
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		return req.SetBody(buf)
+	}
+}
+
+func WithForm(fields map[string]string) RequestOption {
+	return func(req *retryablehttp.Request) error {
+		buf := new(bytes.Buffer)
+		w := multipart.NewWriter(buf)
+
+		for key, value := range fields {
+			if err := w.WriteField(key, value); err != nil {
+				return err
+			}
+		}
+
+		if err := w.Close(); err != nil {
+			return err
+		}
+
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		return req.SetBody(buf)
+	}
+}
+
 func (c *Client) NewRequest(method string, endpoint string, options ...RequestOption) (*retryablehttp.Request, error) {
 	req, err := retryablehttp.NewRequest(method, c.baseURL.JoinPath(endpoint).String(), nil)
 	if err != nil {